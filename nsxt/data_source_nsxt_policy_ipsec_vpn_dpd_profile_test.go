@@ -0,0 +1,93 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func TestAccDataSourceNsxtPolicyIpsecVpnDpdProfile_basic(t *testing.T) {
+	name := getAccTestDataSourceName()
+	testResourceName := "data.nsxt_policy_ipsec_vpn_dpd_profile.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccDataSourceNsxtPolicyIpsecVpnDpdProfileDeleteByName(name)
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if err := testAccDataSourceNsxtPolicyIpsecVpnDpdProfileCreate(name); err != nil {
+						panic(err)
+					}
+				},
+				Config: testAccNsxtPolicyIpsecVpnDpdProfileReadTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "display_name", name),
+					resource.TestCheckResourceAttr(testResourceName, "description", name),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceNsxtPolicyIpsecVpnDpdProfileCreate(name string) error {
+	connector, err := testAccGetPolicyConnector()
+	if err != nil {
+		return fmt.Errorf("Error during test client initialization: %v", err)
+	}
+
+	displayName := name
+	description := name
+	obj := model.IPSecVpnDpdProfile{
+		Description: &description,
+		DisplayName: &displayName,
+	}
+
+	// Generate a random ID for the resource
+	uuid, _ := uuid.NewRandom()
+	id := uuid.String()
+
+	client := getIPSecVpnDpdProfilesClient(connector, testAccIsGlobalManager())
+	err = client.Patch(id, obj)
+	if err != nil {
+		return fmt.Errorf("Error during IPSec VPN DPD Profile creation: %v", err)
+	}
+	return nil
+}
+
+func testAccDataSourceNsxtPolicyIpsecVpnDpdProfileDeleteByName(name string) error {
+	connector, err := testAccGetPolicyConnector()
+	if err != nil {
+		return fmt.Errorf("Error during test client initialization: %v", err)
+	}
+
+	// Find the object by name
+	objID, err := testGetObjIDByName(name, "IPSecVpnDpdProfile")
+	if err != nil {
+		return nil
+	}
+	client := getIPSecVpnDpdProfilesClient(connector, testAccIsGlobalManager())
+	err = client.Delete(objID)
+	if err != nil {
+		return fmt.Errorf("Error during IPSec VPN DPD Profile deletion: %v", err)
+	}
+	return nil
+}
+
+func testAccNsxtPolicyIpsecVpnDpdProfileReadTemplate(name string) string {
+	return fmt.Sprintf(`
+data "nsxt_policy_ipsec_vpn_dpd_profile" "test" {
+  display_name = "%s"
+}`, name)
+}
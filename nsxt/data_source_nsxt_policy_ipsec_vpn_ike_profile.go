@@ -9,7 +9,6 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 )
 
@@ -29,21 +28,12 @@ func dataSourceNsxtPolicyIPSecVpnIkeProfile() *schema.Resource {
 func dataSourceNsxtPolicyIPSecVpnIkeProfileRead(d *schema.ResourceData, m interface{}) error {
 	connector := getPolicyConnector(m)
 
-	if isPolicyGlobalManager(m) {
-		_, err := policyDataSourceResourceRead(d, connector, false, "IPSecVpnIkeProfile", nil)
-		if err != nil {
-			return err
-		}
-
-		return nil
-	}
-
 	objID := d.Get("id").(string)
 	objName := d.Get("display_name").(string)
 	log.Println("########################################################")
 	log.Println(objName)
 	log.Println("########################################################")
-	client := infra.NewDefaultIpsecVpnIkeProfilesClient(connector)
+	client := getIPSecVpnIkeProfilesClient(connector, isPolicyGlobalManager(m))
 
 	var obj model.IPSecVpnIkeProfile
 	if objID != "" {
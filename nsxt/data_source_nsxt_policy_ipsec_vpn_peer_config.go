@@ -0,0 +1,883 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+const (
+	ipSecVpnPeerConfigFormatSwanctl    = "swanctl"
+	ipSecVpnPeerConfigFormatCiscoIos   = "cisco_ios"
+	ipSecVpnPeerConfigFormatJuniperSrx = "juniper_srx"
+	ipSecVpnPeerConfigFormatJSON       = "json"
+
+	ipSecVpnPeerConfigPskPlaceholder         = "<PRE_SHARED_KEY>"
+	ipSecVpnPeerConfigInterfacePlaceholder   = "<EXTERNAL_INTERFACE>"
+	ipSecVpnPeerConfigUnsupportedPlaceholder = "<UNSUPPORTED_ALGORITHM>"
+)
+
+var IPSecVpnPeerConfigFormatValues = []string{
+	ipSecVpnPeerConfigFormatSwanctl,
+	ipSecVpnPeerConfigFormatCiscoIos,
+	ipSecVpnPeerConfigFormatJuniperSrx,
+	ipSecVpnPeerConfigFormatJSON,
+}
+
+var ipSecVpnPeerConfigDhGroups = map[string]string{
+	model.IPSecVpnIkeProfile_DH_GROUPS_GROUP2:  "modp1024",
+	model.IPSecVpnIkeProfile_DH_GROUPS_GROUP5:  "modp1536",
+	model.IPSecVpnIkeProfile_DH_GROUPS_GROUP14: "modp2048",
+	model.IPSecVpnIkeProfile_DH_GROUPS_GROUP15: "modp3072",
+	model.IPSecVpnIkeProfile_DH_GROUPS_GROUP16: "modp4096",
+	model.IPSecVpnIkeProfile_DH_GROUPS_GROUP19: "ecp256",
+	model.IPSecVpnIkeProfile_DH_GROUPS_GROUP20: "ecp384",
+	model.IPSecVpnIkeProfile_DH_GROUPS_GROUP21: "ecp521",
+}
+
+var ipSecVpnPeerConfigSwanctlEncryption = map[string]string{
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_AES_128:                         "aes128",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_AES_256:                         "aes256",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_AES_GCM_128:                     "aes128gcm16",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_AES_GCM_192:                     "aes192gcm16",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_AES_GCM_256:                     "aes256gcm16",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_NO_ENCRYPTION_AUTH_AES_GMAC_128: "aes128gmac",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_NO_ENCRYPTION_AUTH_AES_GMAC_192: "aes192gmac",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_NO_ENCRYPTION_AUTH_AES_GMAC_256: "aes256gmac",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_NO_ENCRYPTION:                   "null",
+}
+
+var ipSecVpnPeerConfigDigest = map[string]string{
+	model.IPSecVpnTunnelProfile_DIGEST_ALGORITHMS_SHA1:     "sha1",
+	model.IPSecVpnTunnelProfile_DIGEST_ALGORITHMS_SHA2_256: "sha256",
+	model.IPSecVpnTunnelProfile_DIGEST_ALGORITHMS_SHA2_384: "sha384",
+	model.IPSecVpnTunnelProfile_DIGEST_ALGORITHMS_SHA2_512: "sha512",
+}
+
+var ipSecVpnPeerConfigCiscoIkev2Encryption = map[string]string{
+	model.IPSecVpnIkeProfile_ENCRYPTION_ALGORITHMS_128:     "aes-cbc-128",
+	model.IPSecVpnIkeProfile_ENCRYPTION_ALGORITHMS_256:     "aes-cbc-256",
+	model.IPSecVpnIkeProfile_ENCRYPTION_ALGORITHMS_GCM_128: "aes-gcm-128",
+	model.IPSecVpnIkeProfile_ENCRYPTION_ALGORITHMS_GCM_256: "aes-gcm-256",
+}
+
+var ipSecVpnPeerConfigCiscoIkev1Encryption = map[string]string{
+	model.IPSecVpnIkeProfile_ENCRYPTION_ALGORITHMS_128: "aes",
+	model.IPSecVpnIkeProfile_ENCRYPTION_ALGORITHMS_256: "aes 256",
+}
+
+var ipSecVpnPeerConfigCiscoIkev1Digest = map[string]string{
+	model.IPSecVpnIkeProfile_DIGEST_ALGORITHMS_SHA1:     "sha",
+	model.IPSecVpnIkeProfile_DIGEST_ALGORITHMS_SHA2_256: "sha256",
+	model.IPSecVpnIkeProfile_DIGEST_ALGORITHMS_SHA2_384: "sha384",
+	model.IPSecVpnIkeProfile_DIGEST_ALGORITHMS_SHA2_512: "sha512",
+}
+
+var ipSecVpnPeerConfigCiscoEspEncryption = map[string]string{
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_AES_128:       "esp-aes",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_AES_256:       "esp-aes 256",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_AES_GCM_128:   "esp-gcm",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_AES_GCM_256:   "esp-gcm 256",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_NO_ENCRYPTION: "esp-null",
+}
+
+var ipSecVpnPeerConfigCiscoEspDigest = map[string]string{
+	model.IPSecVpnTunnelProfile_DIGEST_ALGORITHMS_SHA1:     "esp-sha-hmac",
+	model.IPSecVpnTunnelProfile_DIGEST_ALGORITHMS_SHA2_256: "esp-sha256-hmac",
+	model.IPSecVpnTunnelProfile_DIGEST_ALGORITHMS_SHA2_384: "esp-sha384-hmac",
+	model.IPSecVpnTunnelProfile_DIGEST_ALGORITHMS_SHA2_512: "esp-sha512-hmac",
+}
+
+var ipSecVpnPeerConfigJuniperEncryption = map[string]string{
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_AES_128:     "aes-128-cbc",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_AES_256:     "aes-256-cbc",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_AES_GCM_128: "aes-128-gcm",
+	model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_AES_GCM_256: "aes-256-gcm",
+}
+
+var ipSecVpnPeerConfigJuniperIkeDigest = map[string]string{
+	model.IPSecVpnIkeProfile_DIGEST_ALGORITHMS_SHA1:     "sha1",
+	model.IPSecVpnIkeProfile_DIGEST_ALGORITHMS_SHA2_256: "sha-256",
+	model.IPSecVpnIkeProfile_DIGEST_ALGORITHMS_SHA2_384: "sha-384",
+	model.IPSecVpnIkeProfile_DIGEST_ALGORITHMS_SHA2_512: "sha-512",
+}
+
+var ipSecVpnPeerConfigJuniperEspDigest = map[string]string{
+	model.IPSecVpnTunnelProfile_DIGEST_ALGORITHMS_SHA1:     "hmac-sha1-96",
+	model.IPSecVpnTunnelProfile_DIGEST_ALGORITHMS_SHA2_256: "hmac-sha-256-128",
+	model.IPSecVpnTunnelProfile_DIGEST_ALGORITHMS_SHA2_384: "hmac-sha-384",
+	model.IPSecVpnTunnelProfile_DIGEST_ALGORITHMS_SHA2_512: "hmac-sha-512",
+}
+
+// Peer side view of an IPSec VPN session: local attributes describe the peer
+// device, remote attributes describe the NSX local endpoint
+type ipSecVpnPeerConfig struct {
+	Name               string                       `json:"name"`
+	VpnType            string                       `json:"vpn_type"`
+	AuthenticationMode string                       `json:"authentication_mode"`
+	LocalAddress       string                       `json:"local_address"`
+	LocalID            string                       `json:"local_id"`
+	RemoteAddress      string                       `json:"remote_address"`
+	RemoteID           string                       `json:"remote_id"`
+	Psk                string                       `json:"psk,omitempty"`
+	Interface          string                       `json:"interface,omitempty"`
+	XfrmInterfaceID    uint32                       `json:"-"`
+	Ike                ipSecVpnPeerConfigIke        `json:"ike"`
+	Esp                ipSecVpnPeerConfigEsp        `json:"esp"`
+	TunnelInterfaces   []ipSecVpnPeerConfigVti      `json:"tunnel_interfaces,omitempty"`
+	Rules              []ipSecVpnPeerConfigSelector `json:"rules,omitempty"`
+}
+
+type ipSecVpnPeerConfigIke struct {
+	Version              string   `json:"version"`
+	EncryptionAlgorithms []string `json:"encryption_algorithms"`
+	DigestAlgorithms     []string `json:"digest_algorithms"`
+	DhGroups             []string `json:"dh_groups"`
+	SaLifeTime           int64    `json:"sa_life_time"`
+}
+
+type ipSecVpnPeerConfigEsp struct {
+	EncryptionAlgorithms []string `json:"encryption_algorithms"`
+	DigestAlgorithms     []string `json:"digest_algorithms"`
+	PfsDhGroups          []string `json:"pfs_dh_groups"`
+	SaLifeTime           int64    `json:"sa_life_time"`
+	DfPolicy             string   `json:"df_policy,omitempty"`
+}
+
+type ipSecVpnPeerConfigVti struct {
+	LocalAddress  string `json:"local_address"`
+	RemoteAddress string `json:"remote_address"`
+	PrefixLength  int64  `json:"prefix_length"`
+}
+
+type ipSecVpnPeerConfigSelector struct {
+	LocalSubnets  []string `json:"local_subnets"`
+	RemoteSubnets []string `json:"remote_subnets"`
+}
+
+func dataSourceNsxtPolicyIPSecVpnPeerConfig() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtPolicyIPSecVpnPeerConfigRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": getDataSourceIDSchema(),
+			"session_path": {
+				Type:         schema.TypeString,
+				Description:  "Policy path of the IPSec VPN session",
+				Required:     true,
+				ValidateFunc: validatePolicyPath(),
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Description:  "Format of the peer configuration",
+				Optional:     true,
+				Default:      ipSecVpnPeerConfigFormatSwanctl,
+				ValidateFunc: validation.StringInSlice(IPSecVpnPeerConfigFormatValues, false),
+			},
+			"psk": {
+				Type:        schema.TypeString,
+				Description: "Pre-shared key to render into the configuration. NSX does not return the key, a placeholder is rendered if not set",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"peer_interface": {
+				Type:        schema.TypeString,
+				Description: "Name of the external interface on the peer device",
+				Optional:    true,
+			},
+			"peer_xfrm_interface_id": {
+				Type:         schema.TypeInt,
+				Description:  "XFRM interface ID for route based sessions in swanctl format. Derived from session ID if not set",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"config": {
+				Type:        schema.TypeString,
+				Description: "Rendered peer configuration",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func getIPSecVpnPeerConfigAlgorithms(values []string, mapping map[string]string) []string {
+	var result []string
+	for _, value := range values {
+		if mapped, ok := mapping[value]; ok {
+			result = append(result, mapped)
+		}
+	}
+	return result
+}
+
+func getIPSecVpnPeerConfigFirstAlgorithm(values []string, mapping map[string]string) string {
+	result := getIPSecVpnPeerConfigAlgorithms(values, mapping)
+	if len(result) == 0 {
+		// None of the configured algorithms is supported by the peer platform
+		return ipSecVpnPeerConfigUnsupportedPlaceholder
+	}
+	return result[0]
+}
+
+func isIPSecVpnPeerConfigAeadAlgorithm(algorithm string) bool {
+	return strings.Contains(algorithm, "GCM") || strings.Contains(algorithm, "GMAC")
+}
+
+func getIPSecVpnPeerConfigName(displayName string) string {
+	re := regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+	name := strings.Trim(re.ReplaceAllString(displayName, "-"), "-")
+	if name == "" {
+		return "nsx-vpn"
+	}
+	return name
+}
+
+// Escapes backslashes and double quotes for values rendered inside a quoted string
+func getIPSecVpnPeerConfigQuotedValue(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(value)
+}
+
+// Returns identity type keyword and whether the identity is an IP address
+func getIPSecVpnPeerConfigIdentityType(identity string, isPsk bool) (string, bool) {
+	if net.ParseIP(identity) != nil {
+		return "address", true
+	}
+	if isPsk {
+		return "fqdn", false
+	}
+	return "dn", false
+}
+
+func getIPSecVpnPeerConfigDhGroupNumber(group string) string {
+	return strings.TrimPrefix(group, "GROUP")
+}
+
+func getIPSecVpnPeerConfigVtis(session model.RouteBasedIPSecVpnSession) []ipSecVpnPeerConfigVti {
+	var vtis []ipSecVpnPeerConfigVti
+	for _, vti := range session.TunnelInterfaces {
+		for _, ipSubnet := range vti.IpSubnets {
+			if ipSubnet.PrefixLength == nil {
+				continue
+			}
+			for _, address := range ipSubnet.IpAddresses {
+				vtis = append(vtis, ipSecVpnPeerConfigVti{
+					LocalAddress:  getIPSecVpnTunnelInterfacePeerAddress(address, *ipSubnet.PrefixLength),
+					RemoteAddress: address,
+					PrefixLength:  *ipSubnet.PrefixLength,
+				})
+			}
+		}
+	}
+	return vtis
+}
+
+func getIPSecVpnPeerConfigSelectors(session model.PolicyBasedIPSecVpnSession) []ipSecVpnPeerConfigSelector {
+	var selectors []ipSecVpnPeerConfigSelector
+	for _, rule := range sortIPSecVpnRulesBySequenceNumber(session.Rules) {
+		if rule.Enabled != nil && !*rule.Enabled {
+			continue
+		}
+		// Rule sources are NSX side subnets, hence remote for the peer
+		selectors = append(selectors, ipSecVpnPeerConfigSelector{
+			LocalSubnets:  getIPSecVpnSubnetsStringList(rule.Destinations),
+			RemoteSubnets: getIPSecVpnSubnetsStringList(rule.Sources),
+		})
+	}
+	return selectors
+}
+
+func getIPSecVpnPeerConfigSessionName(session model.IPSecVpnSession) string {
+	if session.DisplayName != nil && *session.DisplayName != "" {
+		return getIPSecVpnPeerConfigName(*session.DisplayName)
+	}
+	if session.Id != nil {
+		return getIPSecVpnPeerConfigName(*session.Id)
+	}
+	return getIPSecVpnPeerConfigName("")
+}
+
+// Derive XFRM interface ID from session ID, so that peers terminating several
+// NSX sessions do not end up with colliding interface IDs
+func getIPSecVpnPeerConfigXfrmInterfaceID(sessionID string) uint32 {
+	id := crc32.ChecksumIEEE([]byte(sessionID)) & 0x7fffffff
+	if id == 0 {
+		return 1
+	}
+	return id
+}
+
+// Builds peer configuration from session attributes, local endpoint and profiles are
+// applied separately
+func getIPSecVpnPeerConfigFromSession(obj *data.StructValue) (*ipSecVpnPeerConfig, model.IPSecVpnSession, error) {
+	converter := bindings.NewTypeConverter()
+	converter.SetMode(bindings.REST)
+
+	baseObj, errs := converter.ConvertToGolang(obj, model.IPSecVpnSessionBindingType())
+	if len(errs) > 0 {
+		return nil, model.IPSecVpnSession{}, fmt.Errorf("Error converting VPN Session %s", errs[0])
+	}
+	session := baseObj.(model.IPSecVpnSession)
+
+	config := ipSecVpnPeerConfig{
+		Name:               getIPSecVpnPeerConfigSessionName(session),
+		VpnType:            session.ResourceType,
+		AuthenticationMode: model.IPSecVpnSession_AUTHENTICATION_MODE_PSK,
+	}
+	if session.AuthenticationMode != nil {
+		config.AuthenticationMode = *session.AuthenticationMode
+	}
+	if session.PeerAddress != nil {
+		config.LocalAddress = *session.PeerAddress
+	}
+	config.LocalID = config.LocalAddress
+	if session.PeerId != nil {
+		config.LocalID = *session.PeerId
+	}
+	if session.Id != nil {
+		config.XfrmInterfaceID = getIPSecVpnPeerConfigXfrmInterfaceID(*session.Id)
+	}
+
+	if session.ResourceType == model.IPSecVpnSession_RESOURCE_TYPE_ROUTEBASEDIPSECVPNSESSION {
+		routeObj, errs := converter.ConvertToGolang(obj, model.RouteBasedIPSecVpnSessionBindingType())
+		if len(errs) > 0 {
+			return nil, session, fmt.Errorf("Error converting VPN Session %s", errs[0])
+		}
+		config.TunnelInterfaces = getIPSecVpnPeerConfigVtis(routeObj.(model.RouteBasedIPSecVpnSession))
+	} else {
+		policyObj, errs := converter.ConvertToGolang(obj, model.PolicyBasedIPSecVpnSessionBindingType())
+		if len(errs) > 0 {
+			return nil, session, fmt.Errorf("Error converting VPN Session %s", errs[0])
+		}
+		config.Rules = getIPSecVpnPeerConfigSelectors(policyObj.(model.PolicyBasedIPSecVpnSession))
+	}
+
+	return &config, session, nil
+}
+
+func setIPSecVpnPeerConfigLocalEndpoint(config *ipSecVpnPeerConfig, endpoint model.IPSecVpnLocalEndpoint) {
+	if endpoint.LocalAddress != nil {
+		config.RemoteAddress = *endpoint.LocalAddress
+	}
+	config.RemoteID = config.RemoteAddress
+	if endpoint.LocalId != nil && *endpoint.LocalId != "" {
+		config.RemoteID = *endpoint.LocalId
+	}
+}
+
+func setIPSecVpnPeerConfigProfiles(config *ipSecVpnPeerConfig, ikeProfile model.IPSecVpnIkeProfile, tunnelProfile model.IPSecVpnTunnelProfile) {
+	config.Ike = ipSecVpnPeerConfigIke{
+		EncryptionAlgorithms: ikeProfile.EncryptionAlgorithms,
+		DigestAlgorithms:     ikeProfile.DigestAlgorithms,
+		DhGroups:             ikeProfile.DhGroups,
+	}
+	if ikeProfile.IkeVersion != nil {
+		config.Ike.Version = *ikeProfile.IkeVersion
+	}
+	if ikeProfile.SaLifeTime != nil {
+		config.Ike.SaLifeTime = *ikeProfile.SaLifeTime
+	}
+
+	config.Esp = ipSecVpnPeerConfigEsp{
+		EncryptionAlgorithms: tunnelProfile.EncryptionAlgorithms,
+		DigestAlgorithms:     tunnelProfile.DigestAlgorithms,
+	}
+	if tunnelProfile.EnablePerfectForwardSecrecy == nil || *tunnelProfile.EnablePerfectForwardSecrecy {
+		config.Esp.PfsDhGroups = tunnelProfile.DhGroups
+	}
+	if tunnelProfile.SaLifeTime != nil {
+		config.Esp.SaLifeTime = *tunnelProfile.SaLifeTime
+	}
+	if tunnelProfile.DfPolicy != nil {
+		config.Esp.DfPolicy = *tunnelProfile.DfPolicy
+	}
+}
+
+func getIPSecVpnPeerConfig(connector *client.RestConnector, servicePath string, sessionID string) (*ipSecVpnPeerConfig, error) {
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	obj, err := getNsxtPolicyIPSecVpnSession(connector, false, isT0, gwID, localeServiceID, serviceID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	config, session, err := getIPSecVpnPeerConfigFromSession(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.LocalEndpointPath == nil {
+		return nil, fmt.Errorf("IPSec VPN Session %s has no local endpoint", sessionID)
+	}
+	endpointServicePath, endpointID := parseVpnServiceChildPolicyPath(*session.LocalEndpointPath, "ipsec-vpn-services", "local-endpoints")
+	if endpointServicePath == "" {
+		return nil, fmt.Errorf("IPSec VPN Local Endpoint path expected, got %s", *session.LocalEndpointPath)
+	}
+	isT0, gwID, localeServiceID, serviceID = parseIPSecVpnServicePolicyPath(endpointServicePath)
+	endpoint, err := getNsxtPolicyIPSecVpnLocalEndpoint(connector, false, isT0, gwID, localeServiceID, serviceID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	setIPSecVpnPeerConfigLocalEndpoint(config, endpoint)
+
+	if session.IkeProfilePath == nil || session.TunnelProfilePath == nil {
+		return nil, fmt.Errorf("IPSec VPN Session %s has no IKE or tunnel profile assigned", sessionID)
+	}
+	ikeProfile, err := infra.NewDefaultIpsecVpnIkeProfilesClient(connector).Get(getPolicyIDFromPath(*session.IkeProfilePath))
+	if err != nil {
+		return nil, err
+	}
+	tunnelProfile, err := infra.NewDefaultIpsecVpnTunnelProfilesClient(connector).Get(getPolicyIDFromPath(*session.TunnelProfilePath))
+	if err != nil {
+		return nil, err
+	}
+	setIPSecVpnPeerConfigProfiles(config, ikeProfile, tunnelProfile)
+
+	return config, nil
+}
+
+func getIPSecVpnPeerConfigSwanctlProposal(encryptionAlgorithms []string, digestAlgorithms []string, dhGroups []string, isIke bool) string {
+	var proposals []string
+	var aead, nonAead []string
+	for _, algorithm := range encryptionAlgorithms {
+		if isIPSecVpnPeerConfigAeadAlgorithm(algorithm) {
+			aead = append(aead, algorithm)
+		} else {
+			nonAead = append(nonAead, algorithm)
+		}
+	}
+
+	dh := getIPSecVpnPeerConfigAlgorithms(dhGroups, ipSecVpnPeerConfigDhGroups)
+	digests := getIPSecVpnPeerConfigAlgorithms(digestAlgorithms, ipSecVpnPeerConfigDigest)
+	if len(nonAead) > 0 {
+		parts := getIPSecVpnPeerConfigAlgorithms(nonAead, ipSecVpnPeerConfigSwanctlEncryption)
+		parts = append(parts, digests...)
+		parts = append(parts, dh...)
+		proposals = append(proposals, strings.Join(parts, "-"))
+	}
+	if len(aead) > 0 {
+		parts := getIPSecVpnPeerConfigAlgorithms(aead, ipSecVpnPeerConfigSwanctlEncryption)
+		if isIke {
+			// AEAD ciphers require an explicit pseudo random function in IKE
+			for _, digest := range digests {
+				parts = append(parts, "prf"+digest)
+			}
+		}
+		parts = append(parts, dh...)
+		proposals = append(proposals, strings.Join(parts, "-"))
+	}
+
+	return strings.Join(proposals, ",")
+}
+
+func renderIPSecVpnPeerConfigSwanctl(config *ipSecVpnPeerConfig) string {
+	var b strings.Builder
+	isPsk := config.AuthenticationMode == model.IPSecVpnSession_AUTHENTICATION_MODE_PSK
+
+	version := "0"
+	if config.Ike.Version == model.IPSecVpnIkeProfile_IKE_VERSION_V1 {
+		version = "1"
+	} else if config.Ike.Version == model.IPSecVpnIkeProfile_IKE_VERSION_V2 {
+		version = "2"
+	}
+
+	fmt.Fprintf(&b, "connections {\n")
+	fmt.Fprintf(&b, "  %s {\n", config.Name)
+	fmt.Fprintf(&b, "    version = %s\n", version)
+	fmt.Fprintf(&b, "    local_addrs = %s\n", config.LocalAddress)
+	fmt.Fprintf(&b, "    remote_addrs = %s\n", config.RemoteAddress)
+	fmt.Fprintf(&b, "    proposals = %s\n", getIPSecVpnPeerConfigSwanctlProposal(config.Ike.EncryptionAlgorithms, config.Ike.DigestAlgorithms, config.Ike.DhGroups, true))
+	if config.Ike.SaLifeTime > 0 {
+		fmt.Fprintf(&b, "    rekey_time = %ds\n", config.Ike.SaLifeTime)
+	}
+	for _, side := range []struct{ name, id, extra string }{{"local", config.LocalID, "peer-cert.pem"}, {"remote", config.RemoteID, ""}} {
+		fmt.Fprintf(&b, "    %s {\n", side.name)
+		if isPsk {
+			fmt.Fprintf(&b, "      auth = psk\n")
+		} else {
+			fmt.Fprintf(&b, "      auth = pubkey\n")
+			if side.extra != "" {
+				fmt.Fprintf(&b, "      certs = %s\n", side.extra)
+			}
+		}
+		fmt.Fprintf(&b, "      id = %s\n", side.id)
+		fmt.Fprintf(&b, "    }\n")
+	}
+
+	espProposal := getIPSecVpnPeerConfigSwanctlProposal(config.Esp.EncryptionAlgorithms, config.Esp.DigestAlgorithms, config.Esp.PfsDhGroups, false)
+	var selectors []ipSecVpnPeerConfigSelector
+	if config.VpnType == model.IPSecVpnSession_RESOURCE_TYPE_ROUTEBASEDIPSECVPNSESSION {
+		selectors = []ipSecVpnPeerConfigSelector{{LocalSubnets: []string{"0.0.0.0/0", "::/0"}, RemoteSubnets: []string{"0.0.0.0/0", "::/0"}}}
+	} else {
+		selectors = config.Rules
+	}
+	fmt.Fprintf(&b, "    children {\n")
+	for i, selector := range selectors {
+		childName := config.Name
+		if len(selectors) > 1 {
+			childName = fmt.Sprintf("%s-%d", config.Name, i+1)
+		}
+		fmt.Fprintf(&b, "      %s {\n", childName)
+		fmt.Fprintf(&b, "        local_ts = %s\n", strings.Join(selector.LocalSubnets, ","))
+		fmt.Fprintf(&b, "        remote_ts = %s\n", strings.Join(selector.RemoteSubnets, ","))
+		fmt.Fprintf(&b, "        esp_proposals = %s\n", espProposal)
+		if config.Esp.SaLifeTime > 0 {
+			fmt.Fprintf(&b, "        rekey_time = %ds\n", config.Esp.SaLifeTime)
+		}
+		if config.VpnType == model.IPSecVpnSession_RESOURCE_TYPE_ROUTEBASEDIPSECVPNSESSION {
+			// Route based sessions are bound to an XFRM interface with matching if_id
+			fmt.Fprintf(&b, "        if_id_in = %d\n", config.XfrmInterfaceID)
+			fmt.Fprintf(&b, "        if_id_out = %d\n", config.XfrmInterfaceID)
+		}
+		fmt.Fprintf(&b, "        start_action = start\n")
+		fmt.Fprintf(&b, "      }\n")
+	}
+	fmt.Fprintf(&b, "    }\n")
+	fmt.Fprintf(&b, "  }\n")
+	fmt.Fprintf(&b, "}\n")
+
+	if isPsk {
+		fmt.Fprintf(&b, "secrets {\n")
+		fmt.Fprintf(&b, "  ike-%s {\n", config.Name)
+		fmt.Fprintf(&b, "    id-local = %s\n", config.LocalID)
+		fmt.Fprintf(&b, "    id-remote = %s\n", config.RemoteID)
+		fmt.Fprintf(&b, "    secret = \"%s\"\n", getIPSecVpnPeerConfigQuotedValue(config.Psk))
+		fmt.Fprintf(&b, "  }\n")
+		fmt.Fprintf(&b, "}\n")
+	}
+
+	if len(config.TunnelInterfaces) > 0 {
+		fmt.Fprintf(&b, "# ip link add %s type xfrm if_id %d\n", config.Name, config.XfrmInterfaceID)
+	}
+	for _, vti := range config.TunnelInterfaces {
+		fmt.Fprintf(&b, "# ip address add %s/%d dev %s\n", vti.LocalAddress, vti.PrefixLength, config.Name)
+	}
+
+	return b.String()
+}
+
+func getIPSecVpnPeerConfigCiscoNetwork(cidr string) (string, bool) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil || network.IP.To4() == nil {
+		return cidr, false
+	}
+	wildcard := make(net.IP, len(network.Mask))
+	for i, b := range network.Mask {
+		wildcard[i] = ^b
+	}
+	return fmt.Sprintf("%s %s", network.IP.String(), wildcard.String()), true
+}
+
+func renderIPSecVpnPeerConfigCiscoIos(config *ipSecVpnPeerConfig) string {
+	var b strings.Builder
+	name := config.Name
+	isPsk := config.AuthenticationMode == model.IPSecVpnSession_AUTHENTICATION_MODE_PSK
+	isIkev1 := config.Ike.Version == model.IPSecVpnIkeProfile_IKE_VERSION_V1
+	isRouteBased := config.VpnType == model.IPSecVpnSession_RESOURCE_TYPE_ROUTEBASEDIPSECVPNSESSION
+
+	if isIkev1 {
+		fmt.Fprintf(&b, "crypto isakmp policy 10\n")
+		fmt.Fprintf(&b, " encryption %s\n", getIPSecVpnPeerConfigFirstAlgorithm(config.Ike.EncryptionAlgorithms, ipSecVpnPeerConfigCiscoIkev1Encryption))
+		fmt.Fprintf(&b, " hash %s\n", getIPSecVpnPeerConfigFirstAlgorithm(config.Ike.DigestAlgorithms, ipSecVpnPeerConfigCiscoIkev1Digest))
+		if isPsk {
+			fmt.Fprintf(&b, " authentication pre-share\n")
+		} else {
+			fmt.Fprintf(&b, " authentication rsa-sig\n")
+		}
+		if len(config.Ike.DhGroups) > 0 {
+			fmt.Fprintf(&b, " group %s\n", getIPSecVpnPeerConfigDhGroupNumber(config.Ike.DhGroups[0]))
+		}
+		if config.Ike.SaLifeTime > 0 {
+			fmt.Fprintf(&b, " lifetime %d\n", config.Ike.SaLifeTime)
+		}
+		if isPsk {
+			fmt.Fprintf(&b, "crypto isakmp key %s address %s\n", config.Psk, config.RemoteAddress)
+		}
+	} else {
+		fmt.Fprintf(&b, "crypto ikev2 proposal %s\n", name)
+		fmt.Fprintf(&b, " encryption %s\n", strings.Join(getIPSecVpnPeerConfigAlgorithms(config.Ike.EncryptionAlgorithms, ipSecVpnPeerConfigCiscoIkev2Encryption), " "))
+		digests := getIPSecVpnPeerConfigAlgorithms(config.Ike.DigestAlgorithms, ipSecVpnPeerConfigDigest)
+		if len(config.Ike.EncryptionAlgorithms) > 0 && isIPSecVpnPeerConfigAeadAlgorithm(config.Ike.EncryptionAlgorithms[0]) {
+			fmt.Fprintf(&b, " prf %s\n", strings.Join(digests, " "))
+		} else {
+			fmt.Fprintf(&b, " integrity %s\n", strings.Join(digests, " "))
+		}
+		var groups []string
+		for _, group := range config.Ike.DhGroups {
+			groups = append(groups, getIPSecVpnPeerConfigDhGroupNumber(group))
+		}
+		fmt.Fprintf(&b, " group %s\n", strings.Join(groups, " "))
+		fmt.Fprintf(&b, "crypto ikev2 policy %s\n", name)
+		fmt.Fprintf(&b, " proposal %s\n", name)
+		if isPsk {
+			fmt.Fprintf(&b, "crypto ikev2 keyring %s\n", name)
+			fmt.Fprintf(&b, " peer %s\n", name)
+			fmt.Fprintf(&b, "  address %s\n", config.RemoteAddress)
+			fmt.Fprintf(&b, "  pre-shared-key %s\n", config.Psk)
+		}
+		fmt.Fprintf(&b, "crypto ikev2 profile %s\n", name)
+		remoteIDType, isIP := getIPSecVpnPeerConfigIdentityType(config.RemoteID, isPsk)
+		if isIP {
+			fmt.Fprintf(&b, " match identity remote address %s 255.255.255.255\n", config.RemoteID)
+		} else if remoteIDType == "dn" {
+			// Distinguished name of NSX certificate is matched with a certificate map
+			fmt.Fprintf(&b, " match certificate <CERTIFICATE_MAP>\n")
+		} else {
+			fmt.Fprintf(&b, " match identity remote %s %s\n", remoteIDType, config.RemoteID)
+		}
+		localIDType, _ := getIPSecVpnPeerConfigIdentityType(config.LocalID, isPsk)
+		if localIDType == "dn" {
+			fmt.Fprintf(&b, " identity local dn\n")
+		} else {
+			fmt.Fprintf(&b, " identity local %s %s\n", localIDType, config.LocalID)
+		}
+		if isPsk {
+			fmt.Fprintf(&b, " authentication remote pre-share\n")
+			fmt.Fprintf(&b, " authentication local pre-share\n")
+			fmt.Fprintf(&b, " keyring local %s\n", name)
+		} else {
+			fmt.Fprintf(&b, " authentication remote rsa-sig\n")
+			fmt.Fprintf(&b, " authentication local rsa-sig\n")
+			fmt.Fprintf(&b, " pki trustpoint <TRUSTPOINT>\n")
+		}
+		if config.Ike.SaLifeTime > 0 {
+			fmt.Fprintf(&b, " lifetime %d\n", config.Ike.SaLifeTime)
+		}
+	}
+
+	transform := []string{getIPSecVpnPeerConfigFirstAlgorithm(config.Esp.EncryptionAlgorithms, ipSecVpnPeerConfigCiscoEspEncryption)}
+	if len(config.Esp.EncryptionAlgorithms) > 0 && !isIPSecVpnPeerConfigAeadAlgorithm(config.Esp.EncryptionAlgorithms[0]) {
+		transform = append(transform, getIPSecVpnPeerConfigFirstAlgorithm(config.Esp.DigestAlgorithms, ipSecVpnPeerConfigCiscoEspDigest))
+	}
+	fmt.Fprintf(&b, "crypto ipsec transform-set %s %s\n", name, strings.Join(transform, " "))
+	fmt.Fprintf(&b, " mode tunnel\n")
+
+	if isRouteBased {
+		fmt.Fprintf(&b, "crypto ipsec profile %s\n", name)
+		fmt.Fprintf(&b, " set transform-set %s\n", name)
+	} else {
+		fmt.Fprintf(&b, "ip access-list extended %s\n", name)
+		for _, selector := range config.Rules {
+			for _, local := range selector.LocalSubnets {
+				for _, remote := range selector.RemoteSubnets {
+					localNetwork, localOk := getIPSecVpnPeerConfigCiscoNetwork(local)
+					remoteNetwork, remoteOk := getIPSecVpnPeerConfigCiscoNetwork(remote)
+					if localOk && remoteOk {
+						fmt.Fprintf(&b, " permit ip %s %s\n", localNetwork, remoteNetwork)
+					} else {
+						fmt.Fprintf(&b, " ! IPv6 selector %s to %s requires an ipv6 access-list\n", local, remote)
+					}
+				}
+			}
+		}
+		fmt.Fprintf(&b, "crypto map %s 10 ipsec-isakmp\n", name)
+		fmt.Fprintf(&b, " set peer %s\n", config.RemoteAddress)
+		fmt.Fprintf(&b, " set transform-set %s\n", name)
+		fmt.Fprintf(&b, " match address %s\n", name)
+	}
+	if len(config.Esp.PfsDhGroups) > 0 {
+		fmt.Fprintf(&b, " set pfs group%s\n", getIPSecVpnPeerConfigDhGroupNumber(config.Esp.PfsDhGroups[0]))
+	}
+	if config.Esp.SaLifeTime > 0 {
+		fmt.Fprintf(&b, " set security-association lifetime seconds %d\n", config.Esp.SaLifeTime)
+	}
+	if !isIkev1 {
+		fmt.Fprintf(&b, " set ikev2-profile %s\n", name)
+	}
+
+	if isRouteBased {
+		fmt.Fprintf(&b, "interface Tunnel1\n")
+		hasIPv4, hasIPv6 := false, false
+		for _, vti := range config.TunnelInterfaces {
+			ip := net.ParseIP(vti.LocalAddress)
+			if ip != nil && ip.To4() != nil {
+				hasIPv4 = true
+				mask := net.CIDRMask(int(vti.PrefixLength), 32)
+				fmt.Fprintf(&b, " ip address %s %s\n", vti.LocalAddress, net.IP(mask).String())
+			} else {
+				hasIPv6 = true
+				fmt.Fprintf(&b, " ipv6 address %s/%d\n", vti.LocalAddress, vti.PrefixLength)
+			}
+		}
+		fmt.Fprintf(&b, " tunnel source %s\n", config.Interface)
+		// Tunnel mode follows address family of the tunnel interface subnets
+		tunnelMode := "ipv4"
+		if hasIPv4 && hasIPv6 {
+			tunnelMode = "dual-overlay"
+		} else if hasIPv6 {
+			tunnelMode = "ipv6"
+		}
+		fmt.Fprintf(&b, " tunnel mode ipsec %s\n", tunnelMode)
+		fmt.Fprintf(&b, " tunnel destination %s\n", config.RemoteAddress)
+		fmt.Fprintf(&b, " tunnel protection ipsec profile %s\n", name)
+	} else {
+		fmt.Fprintf(&b, "interface %s\n", config.Interface)
+		fmt.Fprintf(&b, " crypto map %s\n", name)
+	}
+
+	return b.String()
+}
+
+func renderIPSecVpnPeerConfigJuniperSrx(config *ipSecVpnPeerConfig) string {
+	var b strings.Builder
+	name := config.Name
+	isPsk := config.AuthenticationMode == model.IPSecVpnSession_AUTHENTICATION_MODE_PSK
+
+	ikeEncryption := getIPSecVpnPeerConfigFirstAlgorithm(config.Ike.EncryptionAlgorithms, ipSecVpnPeerConfigJuniperEncryption)
+	if isPsk {
+		fmt.Fprintf(&b, "set security ike proposal %s authentication-method pre-shared-keys\n", name)
+	} else {
+		fmt.Fprintf(&b, "set security ike proposal %s authentication-method rsa-signatures\n", name)
+	}
+	if len(config.Ike.DhGroups) > 0 {
+		fmt.Fprintf(&b, "set security ike proposal %s dh-group %s\n", name, strings.ToLower(config.Ike.DhGroups[0]))
+	}
+	if !strings.HasSuffix(ikeEncryption, "gcm") {
+		fmt.Fprintf(&b, "set security ike proposal %s authentication-algorithm %s\n", name, getIPSecVpnPeerConfigFirstAlgorithm(config.Ike.DigestAlgorithms, ipSecVpnPeerConfigJuniperIkeDigest))
+	}
+	fmt.Fprintf(&b, "set security ike proposal %s encryption-algorithm %s\n", name, ikeEncryption)
+	if config.Ike.SaLifeTime > 0 {
+		fmt.Fprintf(&b, "set security ike proposal %s lifetime-seconds %d\n", name, config.Ike.SaLifeTime)
+	}
+	fmt.Fprintf(&b, "set security ike policy %s proposals %s\n", name, name)
+	if isPsk {
+		fmt.Fprintf(&b, "set security ike policy %s pre-shared-key ascii-text \"%s\"\n", name, getIPSecVpnPeerConfigQuotedValue(config.Psk))
+	} else {
+		fmt.Fprintf(&b, "set security ike policy %s certificate local-certificate <LOCAL_CERTIFICATE>\n", name)
+	}
+	fmt.Fprintf(&b, "set security ike gateway %s ike-policy %s\n", name, name)
+	fmt.Fprintf(&b, "set security ike gateway %s address %s\n", name, config.RemoteAddress)
+	juniperIdentityTypes := map[string]string{"address": "inet", "fqdn": "hostname", "dn": "distinguished-name container"}
+	for _, identity := range []struct{ side, value string }{{"local", config.LocalID}, {"remote", config.RemoteID}} {
+		idType, _ := getIPSecVpnPeerConfigIdentityType(identity.value, isPsk)
+		if idType == "dn" && identity.side == "local" {
+			// Local distinguished name is taken from the local certificate
+			fmt.Fprintf(&b, "set security ike gateway %s local-identity distinguished-name\n", name)
+			continue
+		}
+		fmt.Fprintf(&b, "set security ike gateway %s %s-identity %s %s\n", name, identity.side, juniperIdentityTypes[idType], identity.value)
+	}
+	fmt.Fprintf(&b, "set security ike gateway %s external-interface %s\n", name, config.Interface)
+	if config.Ike.Version == model.IPSecVpnIkeProfile_IKE_VERSION_V1 {
+		fmt.Fprintf(&b, "set security ike gateway %s version v1-only\n", name)
+	} else if config.Ike.Version == model.IPSecVpnIkeProfile_IKE_VERSION_V2 {
+		fmt.Fprintf(&b, "set security ike gateway %s version v2-only\n", name)
+	}
+
+	espEncryption := getIPSecVpnPeerConfigFirstAlgorithm(config.Esp.EncryptionAlgorithms, ipSecVpnPeerConfigJuniperEncryption)
+	fmt.Fprintf(&b, "set security ipsec proposal %s protocol esp\n", name)
+	if !strings.HasSuffix(espEncryption, "gcm") {
+		fmt.Fprintf(&b, "set security ipsec proposal %s authentication-algorithm %s\n", name, getIPSecVpnPeerConfigFirstAlgorithm(config.Esp.DigestAlgorithms, ipSecVpnPeerConfigJuniperEspDigest))
+	}
+	fmt.Fprintf(&b, "set security ipsec proposal %s encryption-algorithm %s\n", name, espEncryption)
+	if config.Esp.SaLifeTime > 0 {
+		fmt.Fprintf(&b, "set security ipsec proposal %s lifetime-seconds %d\n", name, config.Esp.SaLifeTime)
+	}
+	if len(config.Esp.PfsDhGroups) > 0 {
+		fmt.Fprintf(&b, "set security ipsec policy %s perfect-forward-secrecy keys %s\n", name, strings.ToLower(config.Esp.PfsDhGroups[0]))
+	}
+	fmt.Fprintf(&b, "set security ipsec policy %s proposals %s\n", name, name)
+	fmt.Fprintf(&b, "set security ipsec vpn %s ike gateway %s\n", name, name)
+	fmt.Fprintf(&b, "set security ipsec vpn %s ike ipsec-policy %s\n", name, name)
+	fmt.Fprintf(&b, "set security ipsec vpn %s bind-interface st0.0\n", name)
+	fmt.Fprintf(&b, "set security ipsec vpn %s establish-tunnels immediately\n", name)
+
+	for _, vti := range config.TunnelInterfaces {
+		family := "inet"
+		if ip := net.ParseIP(vti.LocalAddress); ip != nil && ip.To4() == nil {
+			family = "inet6"
+		}
+		fmt.Fprintf(&b, "set interfaces st0 unit 0 family %s address %s/%d\n", family, vti.LocalAddress, vti.PrefixLength)
+	}
+
+	// Policy based sessions are expressed as traffic selectors on the bound interface
+	index := 1
+	for _, selector := range config.Rules {
+		for _, local := range selector.LocalSubnets {
+			for _, remote := range selector.RemoteSubnets {
+				fmt.Fprintf(&b, "set security ipsec vpn %s traffic-selector ts%d local-ip %s\n", name, index, local)
+				fmt.Fprintf(&b, "set security ipsec vpn %s traffic-selector ts%d remote-ip %s\n", name, index, remote)
+				index++
+			}
+		}
+	}
+	if len(config.Rules) > 0 {
+		fmt.Fprintf(&b, "set interfaces st0 unit 0 family inet\n")
+	}
+
+	return b.String()
+}
+
+func renderIPSecVpnPeerConfig(config *ipSecVpnPeerConfig, format string) (string, error) {
+	switch format {
+	case ipSecVpnPeerConfigFormatSwanctl:
+		return renderIPSecVpnPeerConfigSwanctl(config), nil
+	case ipSecVpnPeerConfigFormatCiscoIos:
+		return renderIPSecVpnPeerConfigCiscoIos(config), nil
+	case ipSecVpnPeerConfigFormatJuniperSrx:
+		return renderIPSecVpnPeerConfigJuniperSrx(config), nil
+	case ipSecVpnPeerConfigFormatJSON:
+		result, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(result), nil
+	}
+
+	return "", fmt.Errorf("Unsupported peer configuration format %s", format)
+}
+
+func dataSourceNsxtPolicyIPSecVpnPeerConfigRead(d *schema.ResourceData, m interface{}) error {
+	if isPolicyGlobalManager(m) {
+		return localManagerOnlyError()
+	}
+
+	connector := getPolicyConnector(m)
+	sessionPath := d.Get("session_path").(string)
+	format := d.Get("format").(string)
+
+	servicePath, sessionID := parseVpnServiceChildPolicyPath(sessionPath, "ipsec-vpn-services", "sessions")
+	if servicePath == "" {
+		return fmt.Errorf("IPSec VPN Session path expected, got %s", sessionPath)
+	}
+
+	config, err := getIPSecVpnPeerConfig(connector, servicePath, sessionID)
+	if err != nil {
+		return handleDataSourceReadError(d, "IPSec VPN Peer Config", sessionID, err)
+	}
+
+	config.Psk = d.Get("psk").(string)
+	if config.Psk == "" && format != ipSecVpnPeerConfigFormatJSON {
+		config.Psk = ipSecVpnPeerConfigPskPlaceholder
+	}
+	config.Interface = d.Get("peer_interface").(string)
+	if config.Interface == "" && format != ipSecVpnPeerConfigFormatJSON {
+		config.Interface = ipSecVpnPeerConfigInterfacePlaceholder
+	}
+	if interfaceID, ok := d.GetOk("peer_xfrm_interface_id"); ok {
+		config.XfrmInterfaceID = uint32(interfaceID.(int))
+	}
+
+	result, err := renderIPSecVpnPeerConfig(config, format)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(sessionID)
+	d.Set("config", result)
+
+	return nil
+}
@@ -0,0 +1,257 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func TestAccDataSourceNsxtPolicyIPSecVpnPeerConfig_basic(t *testing.T) {
+	name := getAccTestDataSourceName()
+	testDataSourceName := "data.nsxt_policy_ipsec_vpn_peer_config.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIPSecVpnPeerConfigReadTemplate(name, "swanctl"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testDataSourceName, "id"),
+					resource.TestMatchResourceAttr(testDataSourceName, "config", regexp.MustCompile(`remote_addrs = 20.20.0.10`)),
+					resource.TestMatchResourceAttr(testDataSourceName, "config", regexp.MustCompile(`secret = "secret1"`)),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnPeerConfigReadTemplate(name, "cisco_ios"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(testDataSourceName, "config", regexp.MustCompile(`tunnel destination 20.20.0.10`)),
+					resource.TestMatchResourceAttr(testDataSourceName, "config", regexp.MustCompile(`ip address 169.254.152.1 255.255.255.252`)),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnPeerConfigReadTemplate(name, "juniper_srx"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(testDataSourceName, "config", regexp.MustCompile(`set security ike gateway \S+ address 20.20.0.10`)),
+					resource.TestMatchResourceAttr(testDataSourceName, "config", regexp.MustCompile(`family inet address 169.254.152.1/30`)),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnPeerConfigReadTemplate(name, "json"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(testDataSourceName, "config", regexp.MustCompile(`"remote_address": "20.20.0.10"`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccNsxtPolicyIPSecVpnPeerConfigReadTemplate(name string, format string) string {
+	return testAccNsxtPolicyIPSecVpnSessionPrerequisites(true) + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_session" "test" {
+  display_name        = "%s"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "RouteBasedIPSecVpnSession"
+  peer_address        = "18.18.18.19"
+  peer_id             = "18.18.18.19"
+  psk                 = "secret1"
+
+  tunnel_interface {
+    ip_subnet {
+      ip_addresses  = ["169.254.152.2"]
+      prefix_length = 30
+    }
+  }
+}
+
+data "nsxt_policy_ipsec_vpn_peer_config" "test" {
+  session_path   = nsxt_policy_ipsec_vpn_session.test.path
+  format         = "%s"
+  psk            = "secret1"
+  peer_interface = "ge-0/0/0.0"
+}`, name, format)
+}
+
+func testIPSecVpnPeerConfigSessionValue(t *testing.T, session interface{}, bindingType bindings.BindingType) *data.StructValue {
+	converter := bindings.NewTypeConverter()
+	converter.SetMode(bindings.REST)
+	dataValue, errs := converter.ConvertToVapi(session, bindingType)
+	if len(errs) > 0 {
+		t.Fatalf("Failed to convert session: %v", errs[0])
+	}
+	return dataValue.(*data.StructValue)
+}
+
+func testIPSecVpnPeerConfig(t *testing.T, sessionValue *data.StructValue) *ipSecVpnPeerConfig {
+	config, _, err := getIPSecVpnPeerConfigFromSession(sessionValue)
+	if err != nil {
+		t.Fatalf("Failed to build peer configuration: %v", err)
+	}
+
+	localAddress := "20.20.0.10"
+	setIPSecVpnPeerConfigLocalEndpoint(config, model.IPSecVpnLocalEndpoint{LocalAddress: &localAddress})
+
+	ikeVersion := model.IPSecVpnIkeProfile_IKE_VERSION_V2
+	ikeLifeTime := int64(86400)
+	tunnelLifeTime := int64(3600)
+	setIPSecVpnPeerConfigProfiles(config, model.IPSecVpnIkeProfile{
+		IkeVersion:           &ikeVersion,
+		EncryptionAlgorithms: []string{model.IPSecVpnIkeProfile_ENCRYPTION_ALGORITHMS_128},
+		DigestAlgorithms:     []string{model.IPSecVpnIkeProfile_DIGEST_ALGORITHMS_SHA2_256},
+		DhGroups:             []string{model.IPSecVpnIkeProfile_DH_GROUPS_GROUP14},
+		SaLifeTime:           &ikeLifeTime,
+	}, model.IPSecVpnTunnelProfile{
+		EncryptionAlgorithms: []string{model.IPSecVpnTunnelProfile_ENCRYPTION_ALGORITHMS_AES_GCM_128},
+		DhGroups:             []string{model.IPSecVpnTunnelProfile_DH_GROUPS_GROUP14},
+		SaLifeTime:           &tunnelLifeTime,
+	})
+	config.Psk = "secret1"
+	config.Interface = "ge-0/0/0"
+
+	return config
+}
+
+func testIPSecVpnPeerConfigRender(t *testing.T, config *ipSecVpnPeerConfig, format string, expected []string) {
+	result, err := renderIPSecVpnPeerConfig(config, format)
+	if err != nil {
+		t.Fatalf("Failed to render %s peer configuration: %v", format, err)
+	}
+	for _, line := range expected {
+		if !strings.Contains(result, line) {
+			t.Errorf("Expected %s peer configuration to contain %q, got:\n%s", format, line, result)
+		}
+	}
+}
+
+func TestIPSecVpnPeerConfigRouteBased(t *testing.T) {
+	id := "session-1"
+	peerAddress := "18.18.18.19"
+	prefixLength := int64(30)
+	ipv6PrefixLength := int64(126)
+	// Display name is not set, session ID is used for naming
+	session := model.RouteBasedIPSecVpnSession{
+		Id:           &id,
+		ResourceType: model.IPSecVpnSession_RESOURCE_TYPE_ROUTEBASEDIPSECVPNSESSION,
+		PeerAddress:  &peerAddress,
+		PeerId:       &peerAddress,
+		TunnelInterfaces: []model.IPSecVpnTunnelInterface{
+			{
+				IpSubnets: []model.TunnelInterfaceIPSubnet{
+					{IpAddresses: []string{"169.254.152.2"}, PrefixLength: &prefixLength},
+					{IpAddresses: []string{"fd00::2"}, PrefixLength: &ipv6PrefixLength},
+				},
+			},
+		},
+	}
+	config := testIPSecVpnPeerConfig(t, testIPSecVpnPeerConfigSessionValue(t, session, model.RouteBasedIPSecVpnSessionBindingType()))
+	interfaceID := fmt.Sprintf("%d", getIPSecVpnPeerConfigXfrmInterfaceID(id))
+
+	testIPSecVpnPeerConfigRender(t, config, ipSecVpnPeerConfigFormatSwanctl, []string{
+		"  session-1 {",
+		"    local_addrs = 18.18.18.19",
+		"    remote_addrs = 20.20.0.10",
+		"    proposals = aes128-sha256-modp2048",
+		"        esp_proposals = aes128gcm16-modp2048",
+		"        if_id_in = " + interfaceID,
+		"        if_id_out = " + interfaceID,
+		"# ip link add session-1 type xfrm if_id " + interfaceID,
+		"# ip address add 169.254.152.1/30 dev session-1",
+		"# ip address add fd00::1/126 dev session-1",
+		"    secret = \"secret1\"",
+	})
+	testIPSecVpnPeerConfigRender(t, config, ipSecVpnPeerConfigFormatCiscoIos, []string{
+		"crypto ikev2 proposal session-1",
+		" ip address 169.254.152.1 255.255.255.252",
+		" ipv6 address fd00::1/126",
+		" tunnel mode ipsec dual-overlay",
+		" tunnel destination 20.20.0.10",
+	})
+	testIPSecVpnPeerConfigRender(t, config, ipSecVpnPeerConfigFormatJuniperSrx, []string{
+		"set security ike gateway session-1 address 20.20.0.10",
+		"set interfaces st0 unit 0 family inet address 169.254.152.1/30",
+		"set interfaces st0 unit 0 family inet6 address fd00::1/126",
+	})
+	testIPSecVpnPeerConfigRender(t, config, ipSecVpnPeerConfigFormatJSON, []string{
+		`"name": "session-1"`,
+		`"remote_address": "20.20.0.10"`,
+	})
+
+	// IPv6 only tunnel interface
+	session.TunnelInterfaces[0].IpSubnets = session.TunnelInterfaces[0].IpSubnets[1:]
+	config = testIPSecVpnPeerConfig(t, testIPSecVpnPeerConfigSessionValue(t, session, model.RouteBasedIPSecVpnSessionBindingType()))
+	testIPSecVpnPeerConfigRender(t, config, ipSecVpnPeerConfigFormatCiscoIos, []string{
+		" tunnel mode ipsec ipv6",
+	})
+}
+
+func TestIPSecVpnPeerConfigPolicyBased(t *testing.T) {
+	id := "session-2"
+	displayName := "policy session"
+	peerAddress := "18.18.18.19"
+	source := "192.168.10.0/24"
+	destination := "192.169.10.0/24"
+	session := model.PolicyBasedIPSecVpnSession{
+		Id:           &id,
+		DisplayName:  &displayName,
+		ResourceType: model.IPSecVpnSession_RESOURCE_TYPE_POLICYBASEDIPSECVPNSESSION,
+		PeerAddress:  &peerAddress,
+		Rules: []model.IPSecVpnRule{
+			{
+				Sources:      []model.IPSecVpnSubnet{{Subnet: &source}},
+				Destinations: []model.IPSecVpnSubnet{{Subnet: &destination}},
+			},
+		},
+	}
+	config := testIPSecVpnPeerConfig(t, testIPSecVpnPeerConfigSessionValue(t, session, model.PolicyBasedIPSecVpnSessionBindingType()))
+
+	testIPSecVpnPeerConfigRender(t, config, ipSecVpnPeerConfigFormatSwanctl, []string{
+		"  policy-session {",
+		"        local_ts = 192.169.10.0/24",
+		"        remote_ts = 192.168.10.0/24",
+	})
+	testIPSecVpnPeerConfigRender(t, config, ipSecVpnPeerConfigFormatCiscoIos, []string{
+		" permit ip 192.169.10.0 0.0.0.255 192.168.10.0 0.0.0.255",
+		"crypto map policy-session 10 ipsec-isakmp",
+		"interface ge-0/0/0",
+	})
+	testIPSecVpnPeerConfigRender(t, config, ipSecVpnPeerConfigFormatJuniperSrx, []string{
+		"set security ipsec vpn policy-session traffic-selector ts1 local-ip 192.169.10.0/24",
+		"set security ipsec vpn policy-session traffic-selector ts1 remote-ip 192.168.10.0/24",
+	})
+	testIPSecVpnPeerConfigRender(t, config, ipSecVpnPeerConfigFormatJSON, []string{
+		`"vpn_type": "PolicyBasedIPSecVpnSession"`,
+	})
+}
+
+func TestIPSecVpnPeerConfigPskEscaping(t *testing.T) {
+	id := "session-3"
+	peerAddress := "18.18.18.19"
+	session := model.RouteBasedIPSecVpnSession{
+		Id:           &id,
+		ResourceType: model.IPSecVpnSession_RESOURCE_TYPE_ROUTEBASEDIPSECVPNSESSION,
+		PeerAddress:  &peerAddress,
+		PeerId:       &peerAddress,
+	}
+	config := testIPSecVpnPeerConfig(t, testIPSecVpnPeerConfigSessionValue(t, session, model.RouteBasedIPSecVpnSessionBindingType()))
+	config.Psk = `se"cr\et`
+
+	testIPSecVpnPeerConfigRender(t, config, ipSecVpnPeerConfigFormatSwanctl, []string{
+		`    secret = "se\"cr\\et"`,
+	})
+	testIPSecVpnPeerConfigRender(t, config, ipSecVpnPeerConfigFormatJuniperSrx, []string{
+		`set security ike policy session-3 pre-shared-key ascii-text "se\"cr\\et"`,
+	})
+	testIPSecVpnPeerConfigRender(t, config, ipSecVpnPeerConfigFormatJSON, []string{
+		`"psk": "se\"cr\\et"`,
+	})
+}
@@ -0,0 +1,388 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	t0_sessions "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services/ipsec_vpn_services/sessions"
+	t1_sessions "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/locale_services/ipsec_vpn_services/sessions"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+var IPSecVpnSessionRuntimeStatusValues = []string{
+	model.IPSecVpnSessionStatusNsxt_RUNTIME_STATUS_UP,
+	model.IPSecVpnSessionStatusNsxt_RUNTIME_STATUS_DOWN,
+	model.IPSecVpnSessionStatusNsxt_RUNTIME_STATUS_DEGRADED,
+}
+
+var IPSecVpnSessionStatusSourceValues = []string{
+	"realtime",
+	"cached",
+}
+
+func dataSourceNsxtPolicyIPSecVpnSessionStatus() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtPolicyIPSecVpnSessionStatusRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": getDataSourceIDSchema(),
+			"session_path": {
+				Type:         schema.TypeString,
+				Description:  "Policy path of the IPSec VPN session",
+				Required:     true,
+				ValidateFunc: validatePolicyPath(),
+			},
+			"enforcement_point_path": {
+				Type:         schema.TypeString,
+				Description:  "Policy path of the enforcement point to fetch the status from",
+				Optional:     true,
+				ValidateFunc: validatePolicyPath(),
+			},
+			"source": {
+				Type:         schema.TypeString,
+				Description:  "Data source type, realtime or cached",
+				Optional:     true,
+				Default:      "realtime",
+				ValidateFunc: validation.StringInSlice(IPSecVpnSessionStatusSourceValues, false),
+			},
+			"wait_for_status": {
+				Type:         schema.TypeString,
+				Description:  "If set, wait until the session reaches this runtime status",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(IPSecVpnSessionRuntimeStatusValues, false),
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Description:  "Timeout in seconds to wait for the requested status",
+				Optional:     true,
+				Default:      1200,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"delay": {
+				Type:         schema.TypeInt,
+				Description:  "Initial delay to start status checks in seconds",
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"runtime_status": {
+				Type:        schema.TypeString,
+				Description: "Session status consolidated using IKE status and tunnel status",
+				Computed:    true,
+			},
+			"ike_status": {
+				Type:        schema.TypeString,
+				Description: "IKE session status",
+				Computed:    true,
+			},
+			"ike_fail_reason": {
+				Type:        schema.TypeString,
+				Description: "Reason for IKE session failure",
+				Computed:    true,
+			},
+			"total_tunnels": {
+				Type:        schema.TypeInt,
+				Description: "Total number of tunnels",
+				Computed:    true,
+			},
+			"negotiated_tunnels": {
+				Type:        schema.TypeInt,
+				Description: "Number of negotiated tunnels",
+				Computed:    true,
+			},
+			"failed_tunnels": {
+				Type:        schema.TypeInt,
+				Description: "Number of failed tunnels",
+				Computed:    true,
+			},
+			"last_update_timestamp": {
+				Type:        schema.TypeInt,
+				Description: "Timestamp when the data was last updated",
+				Computed:    true,
+			},
+			"aggregate_traffic_counters": {
+				Type:        schema.TypeList,
+				Description: "Aggregate traffic statistics across all tunnels",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: getIPSecVpnTrafficCountersSchema(),
+				},
+			},
+			"tunnel_statistics": {
+				Type:        schema.TypeList,
+				Description: "Traffic statistics per security association",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: getIPSecVpnTunnelStatisticsSchema(),
+				},
+			},
+		},
+	}
+}
+
+func getIPSecVpnTrafficCountersSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"bytes_in": {
+			Type:        schema.TypeInt,
+			Description: "Total number of incoming bytes",
+			Computed:    true,
+		},
+		"bytes_out": {
+			Type:        schema.TypeInt,
+			Description: "Total number of outgoing bytes",
+			Computed:    true,
+		},
+		"packets_in": {
+			Type:        schema.TypeInt,
+			Description: "Total number of incoming packets",
+			Computed:    true,
+		},
+		"packets_out": {
+			Type:        schema.TypeInt,
+			Description: "Total number of outgoing packets",
+			Computed:    true,
+		},
+		"dropped_packets_in": {
+			Type:        schema.TypeInt,
+			Description: "Total number of dropped incoming packets",
+			Computed:    true,
+		},
+		"dropped_packets_out": {
+			Type:        schema.TypeInt,
+			Description: "Total number of dropped outgoing packets",
+			Computed:    true,
+		},
+	}
+}
+
+func getIPSecVpnTunnelStatisticsSchema() map[string]*schema.Schema {
+	elemSchema := getIPSecVpnTrafficCountersSchema()
+	elemSchema["local_subnet"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Local subnet of the tunnel",
+		Computed:    true,
+	}
+	elemSchema["peer_subnet"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Peer subnet of the tunnel",
+		Computed:    true,
+	}
+	elemSchema["tunnel_status"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Tunnel status, UP or DOWN",
+		Computed:    true,
+	}
+	elemSchema["tunnel_down_reason"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Reason for the tunnel being down",
+		Computed:    true,
+	}
+	elemSchema["rule_path"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Policy path of the IPSec VPN rule",
+		Computed:    true,
+	}
+	return elemSchema
+}
+
+func getIPSecVpnTrafficCountersList(counters *model.IPSecVpnTrafficCounters) []map[string]interface{} {
+	var countersList []map[string]interface{}
+	if counters == nil {
+		return countersList
+	}
+	elem := make(map[string]interface{})
+	elem["bytes_in"] = counters.BytesIn
+	elem["bytes_out"] = counters.BytesOut
+	elem["packets_in"] = counters.PacketsIn
+	elem["packets_out"] = counters.PacketsOut
+	elem["dropped_packets_in"] = counters.DroppedPacketsIn
+	elem["dropped_packets_out"] = counters.DroppedPacketsOut
+	return append(countersList, elem)
+}
+
+func getIPSecVpnTunnelStatisticsList(policyStatistics []model.IpSecVpnPolicyTrafficStatistics) []map[string]interface{} {
+	var statsList []map[string]interface{}
+	for _, policyStats := range policyStatistics {
+		for _, tunnelStats := range policyStats.TunnelStatistics {
+			elem := make(map[string]interface{})
+			elem["bytes_in"] = tunnelStats.BytesIn
+			elem["bytes_out"] = tunnelStats.BytesOut
+			elem["packets_in"] = tunnelStats.PacketsIn
+			elem["packets_out"] = tunnelStats.PacketsOut
+			elem["dropped_packets_in"] = tunnelStats.DroppedPacketsIn
+			elem["dropped_packets_out"] = tunnelStats.DroppedPacketsOut
+			elem["local_subnet"] = tunnelStats.LocalSubnet
+			elem["peer_subnet"] = tunnelStats.PeerSubnet
+			elem["tunnel_status"] = tunnelStats.TunnelStatus
+			elem["tunnel_down_reason"] = tunnelStats.TunnelDownReason
+			elem["rule_path"] = policyStats.RulePath
+			statsList = append(statsList, elem)
+		}
+	}
+	return statsList
+}
+
+func getIPSecVpnSessionStatus(connector *client.RestConnector, servicePath string, sessionID string, enforcementPointPath string, source string) (*model.IPSecVpnSessionStatusNsxt, error) {
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	var epPath *string
+	if enforcementPointPath != "" {
+		epPath = &enforcementPointPath
+	}
+
+	var status model.AggregateIPSecVpnSessionStatus
+	var err error
+	if isT0 {
+		client := t0_sessions.NewDefaultDetailedStatusClient(connector)
+		status, err = client.Get(gwID, localeServiceID, serviceID, sessionID, epPath, &source)
+	} else {
+		client := t1_sessions.NewDefaultDetailedStatusClient(connector)
+		status, err = client.Get(gwID, localeServiceID, serviceID, sessionID, epPath, &source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	converter := bindings.NewTypeConverter()
+	converter.SetMode(bindings.REST)
+	for _, result := range status.Results {
+		obj, errs := converter.ConvertToGolang(result, model.IPSecVpnSessionStatusNsxtBindingType())
+		if len(errs) > 0 {
+			return nil, errs[0]
+		}
+		sessionStatus := obj.(model.IPSecVpnSessionStatusNsxt)
+		if enforcementPointPath == "" || (sessionStatus.EnforcementPointPath != nil && *sessionStatus.EnforcementPointPath == enforcementPointPath) {
+			return &sessionStatus, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func getIPSecVpnSessionStatistics(connector *client.RestConnector, servicePath string, sessionID string, enforcementPointPath string, source string) (*model.IPSecVpnSessionStatisticsNsxt, error) {
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	var epPath *string
+	if enforcementPointPath != "" {
+		epPath = &enforcementPointPath
+	}
+
+	var statistics model.AggregateIPSecVpnSessionStatistics
+	var err error
+	if isT0 {
+		client := t0_sessions.NewDefaultStatisticsClient(connector)
+		statistics, err = client.Get(gwID, localeServiceID, serviceID, sessionID, epPath, &source)
+	} else {
+		client := t1_sessions.NewDefaultStatisticsClient(connector)
+		statistics, err = client.Get(gwID, localeServiceID, serviceID, sessionID, epPath, &source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	converter := bindings.NewTypeConverter()
+	converter.SetMode(bindings.REST)
+	for _, result := range statistics.Results {
+		obj, errs := converter.ConvertToGolang(result, model.IPSecVpnSessionStatisticsNsxtBindingType())
+		if len(errs) > 0 {
+			return nil, errs[0]
+		}
+		sessionStatistics := obj.(model.IPSecVpnSessionStatisticsNsxt)
+		if enforcementPointPath == "" || (sessionStatistics.EnforcementPointPath != nil && *sessionStatistics.EnforcementPointPath == enforcementPointPath) {
+			return &sessionStatistics, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func setIPSecVpnSessionStatusInSchema(d *schema.ResourceData, status *model.IPSecVpnSessionStatusNsxt) {
+	d.Set("runtime_status", status.RuntimeStatus)
+	d.Set("total_tunnels", status.TotalTunnels)
+	d.Set("negotiated_tunnels", status.NegotiatedTunnels)
+	d.Set("failed_tunnels", status.FailedTunnels)
+	d.Set("last_update_timestamp", status.LastUpdateTimestamp)
+	if status.IkeStatus != nil {
+		d.Set("ike_status", status.IkeStatus.IkeSessionState)
+		d.Set("ike_fail_reason", status.IkeStatus.FailReason)
+	}
+	d.Set("aggregate_traffic_counters", getIPSecVpnTrafficCountersList(status.AggregateTrafficCounters))
+}
+
+func dataSourceNsxtPolicyIPSecVpnSessionStatusRead(d *schema.ResourceData, m interface{}) error {
+	if isPolicyGlobalManager(m) {
+		return localManagerOnlyError()
+	}
+
+	connector := getPolicyConnector(m)
+
+	sessionPath := d.Get("session_path").(string)
+	enforcementPointPath := d.Get("enforcement_point_path").(string)
+	source := d.Get("source").(string)
+	waitForStatus := d.Get("wait_for_status").(string)
+	delay := d.Get("delay").(int)
+	timeout := d.Get("timeout").(int)
+
+	servicePath, sessionID := parseVpnServiceChildPolicyPath(sessionPath, "ipsec-vpn-services", "sessions")
+	if servicePath == "" {
+		return fmt.Errorf("IPSec VPN Session path expected, got %s", sessionPath)
+	}
+
+	if waitForStatus == "" {
+		status, err := getIPSecVpnSessionStatus(connector, servicePath, sessionID, enforcementPointPath, source)
+		if err != nil {
+			return handleDataSourceReadError(d, "IPSec VPN Session Status", sessionID, err)
+		}
+		if status == nil {
+			return fmt.Errorf("No status found for IPSec VPN Session %s", sessionPath)
+		}
+		setIPSecVpnSessionStatusInSchema(d, status)
+	} else {
+		pendingStates := []string{"UNKNOWN"}
+		for _, value := range IPSecVpnSessionRuntimeStatusValues {
+			if value != waitForStatus {
+				pendingStates = append(pendingStates, value)
+			}
+		}
+		stateConf := &resource.StateChangeConf{
+			Pending: pendingStates,
+			Target:  []string{waitForStatus},
+			Refresh: func() (interface{}, string, error) {
+				status, err := getIPSecVpnSessionStatus(connector, servicePath, sessionID, enforcementPointPath, source)
+				if err != nil {
+					return status, "", err
+				}
+				if status == nil || status.RuntimeStatus == nil {
+					return status, "UNKNOWN", nil
+				}
+				setIPSecVpnSessionStatusInSchema(d, status)
+				return status, *status.RuntimeStatus, nil
+			},
+			Timeout:    time.Duration(timeout) * time.Second,
+			MinTimeout: 1 * time.Second,
+			Delay:      time.Duration(delay) * time.Second,
+		}
+		_, err := stateConf.WaitForState()
+		if err != nil {
+			return fmt.Errorf("Failed to get status %s for IPSec VPN Session %s: %v", waitForStatus, sessionPath, err)
+		}
+	}
+
+	statistics, err := getIPSecVpnSessionStatistics(connector, servicePath, sessionID, enforcementPointPath, source)
+	if err != nil {
+		return handleDataSourceReadError(d, "IPSec VPN Session Statistics", sessionID, err)
+	}
+	if statistics != nil {
+		d.Set("tunnel_statistics", getIPSecVpnTunnelStatisticsList(statistics.PolicyStatistics))
+	}
+
+	d.SetId(sessionID)
+
+	return nil
+}
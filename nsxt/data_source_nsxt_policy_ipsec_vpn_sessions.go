@@ -0,0 +1,291 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	t0_locale_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services"
+	t0_ipsec_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services/ipsec_vpn_services"
+	t1_locale_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/locale_services"
+	t1_ipsec_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/locale_services/ipsec_vpn_services"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func dataSourceNsxtPolicyIPSecVpnSessions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtPolicyIPSecVpnSessionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": getDataSourceIDSchema(),
+			"service_path": {
+				Type:         schema.TypeString,
+				Description:  "Policy path for IPSec VPN service to list sessions from",
+				Optional:     true,
+				ValidateFunc: validateVpnServicePolicyPath("ipsec-vpn-services"),
+				ExactlyOneOf: []string{"service_path", "gateway_path"},
+			},
+			"gateway_path": {
+				Type:         schema.TypeString,
+				Description:  "Policy path for Tier0 or Tier1 gateway to list sessions of all IPSec VPN services from",
+				Optional:     true,
+				ValidateFunc: validatePolicyPath(),
+			},
+			"peer_address": {
+				Type:         schema.TypeString,
+				Description:  "If set, only sessions with this peer address are listed",
+				Optional:     true,
+				ValidateFunc: validateSingleIP(),
+			},
+			"vpn_type": {
+				Type:         schema.TypeString,
+				Description:  "If set, only sessions of this type are listed",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(IPSecVpnSessionResourceType, false),
+			},
+			"enabled": {
+				Type:         schema.TypeString,
+				Description:  "If set to true or false, only enabled or only disabled sessions are listed",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"true", "false"}, false),
+			},
+			"tag": getTagsSchema(),
+			"items": {
+				Type:        schema.TypeList,
+				Description: "IPSec VPN sessions matching the filters",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "ID of the session",
+							Computed:    true,
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Description: "Policy path of the session",
+							Computed:    true,
+						},
+						"display_name": {
+							Type:        schema.TypeString,
+							Description: "Display name of the session",
+							Computed:    true,
+						},
+						"service_path": {
+							Type:        schema.TypeString,
+							Description: "Policy path of the IPSec VPN service the session belongs to",
+							Computed:    true,
+						},
+						"vpn_type": {
+							Type:        schema.TypeString,
+							Description: "Session type, policy based or route based",
+							Computed:    true,
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Description: "Whether the session is enabled",
+							Computed:    true,
+						},
+						"peer_address": {
+							Type:        schema.TypeString,
+							Description: "Public IPv4 address of remote gateway",
+							Computed:    true,
+						},
+						"peer_id": {
+							Type:        schema.TypeString,
+							Description: "Peer ID to uniquely identify the peer site",
+							Computed:    true,
+						},
+						"local_endpoint_path": {
+							Type:        schema.TypeString,
+							Description: "Policy path of the local endpoint",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func listPolicyIPSecVpnServices(connector *client.RestConnector, isT0 bool, gwID string, localeServiceID string) ([]model.IPSecVpnService, error) {
+	var results []model.IPSecVpnService
+	var cursor *string
+	markForDelete := false
+	total := 0
+
+	for {
+		var listResponse model.IPSecVpnServiceListResult
+		var err error
+		if isT0 {
+			client := t0_locale_services.NewDefaultIpsecVpnServicesClient(connector)
+			listResponse, err = client.List(gwID, localeServiceID, cursor, &markForDelete, nil, nil, nil, nil)
+		} else {
+			client := t1_locale_services.NewDefaultIpsecVpnServicesClient(connector)
+			listResponse, err = client.List(gwID, localeServiceID, cursor, &markForDelete, nil, nil, nil, nil)
+		}
+		if err != nil {
+			return results, err
+		}
+		results = append(results, listResponse.Results...)
+		if total == 0 && listResponse.ResultCount != nil {
+			// first response
+			total = int(*listResponse.ResultCount)
+		}
+		cursor = listResponse.Cursor
+		if len(results) >= total {
+			return results, nil
+		}
+	}
+}
+
+func listPolicyIPSecVpnSessions(connector *client.RestConnector, isT0 bool, gwID string, localeServiceID string, serviceID string) ([]*data.StructValue, error) {
+	var results []*data.StructValue
+	var cursor *string
+	markForDelete := false
+	total := 0
+
+	for {
+		var listResponse model.IPSecVpnSessionListResult
+		var err error
+		if isT0 {
+			client := t0_ipsec_services.NewDefaultSessionsClient(connector)
+			listResponse, err = client.List(gwID, localeServiceID, serviceID, cursor, &markForDelete, nil, nil, nil, nil)
+		} else {
+			client := t1_ipsec_services.NewDefaultSessionsClient(connector)
+			listResponse, err = client.List(gwID, localeServiceID, serviceID, cursor, &markForDelete, nil, nil, nil, nil)
+		}
+		if err != nil {
+			return results, err
+		}
+		results = append(results, listResponse.Results...)
+		if total == 0 && listResponse.ResultCount != nil {
+			// first response
+			total = int(*listResponse.ResultCount)
+		}
+		cursor = listResponse.Cursor
+		if len(results) >= total {
+			return results, nil
+		}
+	}
+}
+
+func policyIPSecVpnSessionHasTags(session model.IPSecVpnSession, tags []model.Tag) bool {
+	for _, tag := range tags {
+		found := false
+		for _, sessionTag := range session.Tags {
+			if sessionTag.Scope != nil && sessionTag.Tag != nil && *sessionTag.Scope == *tag.Scope && *sessionTag.Tag == *tag.Tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func dataSourceNsxtPolicyIPSecVpnSessionsRead(d *schema.ResourceData, m interface{}) error {
+	if isPolicyGlobalManager(m) {
+		return localManagerOnlyError()
+	}
+
+	connector := getPolicyConnector(m)
+	converter := bindings.NewTypeConverter()
+	converter.SetMode(bindings.REST)
+
+	servicePath := d.Get("service_path").(string)
+	gwPath := d.Get("gateway_path").(string)
+
+	var sessionValues []*data.StructValue
+	if servicePath != "" {
+		isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+		if gwID == "" {
+			return fmt.Errorf("Invalid IPSec VPN service path %s", servicePath)
+		}
+		sessions, err := listPolicyIPSecVpnSessions(connector, isT0, gwID, localeServiceID, serviceID)
+		if err != nil {
+			return handleListError("IPSec VPN Session", err)
+		}
+		sessionValues = sessions
+		d.SetId(servicePath)
+	} else {
+		isT0, gwID := parseGatewayPolicyPath(gwPath)
+		if gwID == "" {
+			return fmt.Errorf("Invalid gateway path %s", gwPath)
+		}
+		var localeServices []model.LocaleServices
+		var err error
+		if isT0 {
+			localeServices, err = listPolicyTier0GatewayLocaleServices(connector, gwID, false)
+		} else {
+			localeServices, err = listPolicyTier1GatewayLocaleServices(connector, gwID, false)
+		}
+		if err != nil {
+			return handleListError("Gateway Locale Service", err)
+		}
+		for _, localeService := range localeServices {
+			services, err := listPolicyIPSecVpnServices(connector, isT0, gwID, *localeService.Id)
+			if err != nil {
+				return handleListError("IPSec VPN Service", err)
+			}
+			for _, service := range services {
+				sessions, err := listPolicyIPSecVpnSessions(connector, isT0, gwID, *localeService.Id, *service.Id)
+				if err != nil {
+					return handleListError("IPSec VPN Session", err)
+				}
+				sessionValues = append(sessionValues, sessions...)
+			}
+		}
+		d.SetId(gwPath)
+	}
+
+	peerAddress := d.Get("peer_address").(string)
+	vpnType := d.Get("vpn_type").(string)
+	enabled := d.Get("enabled").(string)
+	tags := getPolicyTagsFromSchema(d)
+
+	var itemList []map[string]interface{}
+	for _, sessionValue := range sessionValues {
+		dataValue, errs := converter.ConvertToGolang(sessionValue, model.IPSecVpnSessionBindingType())
+		if len(errs) > 0 {
+			return fmt.Errorf("Error converting IPSec VPN Session %s", errs[0])
+		}
+		session := dataValue.(model.IPSecVpnSession)
+
+		if peerAddress != "" && (session.PeerAddress == nil || *session.PeerAddress != peerAddress) {
+			continue
+		}
+		if vpnType != "" && session.ResourceType != vpnType {
+			continue
+		}
+		if enabled != "" && (session.Enabled == nil || strconv.FormatBool(*session.Enabled) != enabled) {
+			continue
+		}
+		if !policyIPSecVpnSessionHasTags(session, tags) {
+			continue
+		}
+
+		elem := make(map[string]interface{})
+		elem["id"] = session.Id
+		elem["path"] = session.Path
+		elem["display_name"] = session.DisplayName
+		elem["service_path"] = session.ParentPath
+		elem["vpn_type"] = session.ResourceType
+		elem["enabled"] = session.Enabled
+		elem["peer_address"] = session.PeerAddress
+		elem["peer_id"] = session.PeerId
+		elem["local_endpoint_path"] = session.LocalEndpointPath
+		itemList = append(itemList, elem)
+	}
+
+	return d.Set("items", itemList)
+}
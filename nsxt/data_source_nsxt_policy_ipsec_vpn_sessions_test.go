@@ -0,0 +1,144 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceNsxtPolicyIPSecVpnSessions_basic(t *testing.T) {
+	name := getAccTestDataSourceName()
+	testDataSourceName := "data.nsxt_policy_ipsec_vpn_sessions.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionsReadTemplate(name, "service_path = nsxt_policy_ipsec_vpn_service.test.path"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testDataSourceName, "id"),
+					resource.TestCheckResourceAttr(testDataSourceName, "items.#", "2"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionsReadTemplate(name, "gateway_path = nsxt_policy_tier0_gateway.test.path"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testDataSourceName, "items.#", "2"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionsReadTemplate(name, `service_path = nsxt_policy_ipsec_vpn_service.test.path
+  peer_address = "18.18.18.20"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testDataSourceName, "items.#", "1"),
+					resource.TestCheckResourceAttrPair(testDataSourceName, "items.0.path", "nsxt_policy_ipsec_vpn_session.test2", "path"),
+					resource.TestCheckResourceAttrPair(testDataSourceName, "items.0.id", "nsxt_policy_ipsec_vpn_session.test2", "id"),
+					resource.TestCheckResourceAttr(testDataSourceName, "items.0.vpn_type", "PolicyBasedIPSecVpnSession"),
+					resource.TestCheckResourceAttr(testDataSourceName, "items.0.enabled", "false"),
+					resource.TestCheckResourceAttr(testDataSourceName, "items.0.peer_address", "18.18.18.20"),
+					resource.TestCheckResourceAttrPair(testDataSourceName, "items.0.service_path", "nsxt_policy_ipsec_vpn_service.test", "path"),
+					resource.TestCheckResourceAttrPair(testDataSourceName, "items.0.local_endpoint_path", "nsxt_policy_ipsec_vpn_local_endpoint.test", "path"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionsReadTemplate(name, `gateway_path = nsxt_policy_tier0_gateway.test.path
+  vpn_type     = "RouteBasedIPSecVpnSession"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testDataSourceName, "items.#", "1"),
+					resource.TestCheckResourceAttrPair(testDataSourceName, "items.0.path", "nsxt_policy_ipsec_vpn_session.test1", "path"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionsReadTemplate(name, `service_path = nsxt_policy_ipsec_vpn_service.test.path
+  enabled      = "true"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testDataSourceName, "items.#", "1"),
+					resource.TestCheckResourceAttrPair(testDataSourceName, "items.0.path", "nsxt_policy_ipsec_vpn_session.test1", "path"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionsReadTemplate(name, `service_path = nsxt_policy_ipsec_vpn_service.test.path
+  enabled      = "false"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testDataSourceName, "items.#", "1"),
+					resource.TestCheckResourceAttrPair(testDataSourceName, "items.0.path", "nsxt_policy_ipsec_vpn_session.test2", "path"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionsReadTemplate(name, `service_path = nsxt_policy_ipsec_vpn_service.test.path
+
+  tag {
+    scope = "monitoring"
+    tag   = "on"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testDataSourceName, "items.#", "1"),
+					resource.TestCheckResourceAttrPair(testDataSourceName, "items.0.path", "nsxt_policy_ipsec_vpn_session.test2", "path"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNsxtPolicyIPSecVpnSessionsReadTemplate(name string, filter string) string {
+	return testAccNsxtPolicyIPSecVpnServicePrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_service" "test" {
+  display_name = "%s"
+  gateway_path = nsxt_policy_tier0_gateway.test.path
+}
+
+resource "nsxt_policy_ipsec_vpn_local_endpoint" "test" {
+  display_name  = "%s"
+  service_path  = nsxt_policy_ipsec_vpn_service.test.path
+  local_address = "20.20.0.10"
+}
+
+resource "nsxt_policy_ipsec_vpn_session" "test1" {
+  display_name        = "%s-1"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "RouteBasedIPSecVpnSession"
+  peer_address        = "18.18.18.19"
+  peer_id             = "18.18.18.19"
+  psk                 = "secret1"
+
+  tunnel_interface {
+    ip_subnet {
+      ip_addresses  = ["169.254.152.2"]
+      prefix_length = 30
+    }
+  }
+}
+
+resource "nsxt_policy_ipsec_vpn_session" "test2" {
+  display_name        = "%s-2"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "PolicyBasedIPSecVpnSession"
+  peer_address        = "18.18.18.20"
+  peer_id             = "18.18.18.20"
+  psk                 = "secret1"
+  enabled             = false
+
+  rule {
+    sources      = ["192.168.10.0/24"]
+    destinations = ["192.169.10.0/24"]
+  }
+
+  tag {
+    scope = "monitoring"
+    tag   = "on"
+  }
+}
+
+data "nsxt_policy_ipsec_vpn_sessions" "test" {
+  %s
+
+  depends_on = [nsxt_policy_ipsec_vpn_session.test1, nsxt_policy_ipsec_vpn_session.test2]
+}`, name, name, name, name, filter)
+}
@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 )
 
@@ -21,6 +20,69 @@ func dataSourceNsxtPolicyIpsecVpnTunnelProfile() *schema.Resource {
 			"display_name": getDataSourceDisplayNameSchema(),
 			"description":  getDataSourceDescriptionSchema(),
 			"path":         getPathSchema(),
+			"encryption_algorithms": {
+				Type:        schema.TypeSet,
+				Description: "Encryption algorithms used during tunnel negotiation",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"digest_algorithms": {
+				Type:        schema.TypeSet,
+				Description: "Algorithms used for message digest",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"dh_groups": {
+				Type:        schema.TypeSet,
+				Description: "Diffie-Hellman groups used if PFS is enabled",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"enable_perfect_forward_secrecy": {
+				Type:        schema.TypeBool,
+				Description: "Whether perfect forward secrecy is enabled",
+				Computed:    true,
+			},
+			"sa_life_time": {
+				Type:        schema.TypeInt,
+				Description: "SA life time in seconds",
+				Computed:    true,
+			},
+			"df_policy": {
+				Type:        schema.TypeString,
+				Description: "Defragmentation policy for the tunnel",
+				Computed:    true,
+			},
+			"extended_attribute": {
+				Type:        schema.TypeList,
+				Description: "Type specific properties, such as encapsulation mode and transform protocol",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"values": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"data_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -28,18 +90,9 @@ func dataSourceNsxtPolicyIpsecVpnTunnelProfile() *schema.Resource {
 func dataSourceNsxtPolicyIpsecVpnTunnelProfileRead(d *schema.ResourceData, m interface{}) error {
 	connector := getPolicyConnector(m)
 
-	if isPolicyGlobalManager(m) {
-		_, err := policyDataSourceResourceRead(d, connector, false, "IpsecVpnTunnelProfile", nil)
-		if err != nil {
-			return err
-		}
-
-		return nil
-	}
-
 	objID := d.Get("id").(string)
 	objName := d.Get("display_name").(string)
-	client := infra.NewDefaultIpsecVpnTunnelProfilesClient(connector)
+	client := getIPSecVpnTunnelProfilesClient(connector, isPolicyGlobalManager(m))
 	var obj model.IPSecVpnTunnelProfile
 	if objID != "" {
 		// Get by id
@@ -91,5 +144,5 @@ func dataSourceNsxtPolicyIpsecVpnTunnelProfileRead(d *schema.ResourceData, m int
 	d.Set("display_name", obj.DisplayName)
 	d.Set("description", obj.Description)
 	d.Set("path", obj.Path)
-	return nil
+	return setIpsecVpnTunnelProfileAttributesInSchema(d, obj)
 }
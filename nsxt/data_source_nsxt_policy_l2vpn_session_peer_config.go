@@ -0,0 +1,128 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	t0_sessions "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services/l2vpn_services/sessions"
+	t1_sessions "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/locale_services/l2vpn_services/sessions"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func dataSourceNsxtPolicyL2VpnSessionPeerConfig() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtPolicyL2VpnSessionPeerConfigRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": getDataSourceIDSchema(),
+			"session_path": {
+				Type:         schema.TypeString,
+				Description:  "Policy path of the L2VPN session",
+				Required:     true,
+				ValidateFunc: validateL2VpnSessionPolicyPath(),
+			},
+			"enforcement_point_path": {
+				Type:         schema.TypeString,
+				Description:  "Policy path of the enforcement point to fetch the peer config from",
+				Optional:     true,
+				ValidateFunc: validatePolicyPath(),
+			},
+			"peer_code": {
+				Type:        schema.TypeList,
+				Description: "List of peer codes per transport tunnel",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"transport_tunnel_path": {
+							Type:        schema.TypeString,
+							Description: "Policy path of the transport tunnel",
+							Computed:    true,
+						},
+						"peer_code": {
+							Type:        schema.TypeString,
+							Description: "Base64 encoded peer code with all the configuration for the tunnel, including the pre-shared key",
+							Computed:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getL2VpnSessionPeerConfig(connector *client.RestConnector, servicePath string, sessionID string, enforcementPointPath string) (*model.L2VPNSessionPeerConfigNsxt, error) {
+	isT0, gwID, localeServiceID, serviceID := parseL2VpnServicePolicyPath(servicePath)
+	var epPath *string
+	if enforcementPointPath != "" {
+		epPath = &enforcementPointPath
+	}
+
+	var peerConfig model.AggregateL2VPNSessionPeerConfig
+	var err error
+	if isT0 {
+		client := t0_sessions.NewDefaultPeerConfigClient(connector)
+		peerConfig, err = client.Get(gwID, localeServiceID, serviceID, sessionID, epPath)
+	} else {
+		client := t1_sessions.NewDefaultPeerConfigClient(connector)
+		peerConfig, err = client.Get(gwID, localeServiceID, serviceID, sessionID, epPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	converter := bindings.NewTypeConverter()
+	converter.SetMode(bindings.REST)
+	for _, result := range peerConfig.Results {
+		obj, errs := converter.ConvertToGolang(result, model.L2VPNSessionPeerConfigNsxtBindingType())
+		if len(errs) > 0 {
+			return nil, errs[0]
+		}
+		sessionPeerConfig := obj.(model.L2VPNSessionPeerConfigNsxt)
+		if enforcementPointPath == "" || (sessionPeerConfig.EnforcementPointPath != nil && *sessionPeerConfig.EnforcementPointPath == enforcementPointPath) {
+			return &sessionPeerConfig, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func dataSourceNsxtPolicyL2VpnSessionPeerConfigRead(d *schema.ResourceData, m interface{}) error {
+	if isPolicyGlobalManager(m) {
+		return localManagerOnlyError()
+	}
+
+	connector := getPolicyConnector(m)
+
+	sessionPath := d.Get("session_path").(string)
+	enforcementPointPath := d.Get("enforcement_point_path").(string)
+	servicePath, sessionID := parseVpnServiceChildPolicyPath(sessionPath, "l2vpn-services", "sessions")
+	if servicePath == "" {
+		return fmt.Errorf("L2VPN Session path expected, got %s", sessionPath)
+	}
+
+	peerConfig, err := getL2VpnSessionPeerConfig(connector, servicePath, sessionID, enforcementPointPath)
+	if err != nil {
+		return handleDataSourceReadError(d, "L2VPN Session Peer Config", sessionID, err)
+	}
+	if peerConfig == nil {
+		return fmt.Errorf("No peer config found for L2VPN Session %s", sessionPath)
+	}
+
+	var peerCodes []map[string]interface{}
+	for _, peerCode := range peerConfig.PeerCodes {
+		elem := make(map[string]interface{})
+		elem["transport_tunnel_path"] = peerCode.TransportTunnelPath
+		elem["peer_code"] = peerCode.PeerCode
+		peerCodes = append(peerCodes, elem)
+	}
+	d.Set("peer_code", peerCodes)
+	d.SetId(sessionID)
+
+	return nil
+}
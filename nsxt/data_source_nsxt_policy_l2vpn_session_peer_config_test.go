@@ -0,0 +1,79 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceNsxtPolicyL2VpnSessionPeerConfig_basic(t *testing.T) {
+	name := getAccTestDataSourceName()
+	testDataSourceName := "data.nsxt_policy_l2vpn_session_peer_config.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyL2VpnSessionPeerConfigReadTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testDataSourceName, "id"),
+					resource.TestCheckResourceAttr(testDataSourceName, "peer_code.#", "1"),
+					resource.TestCheckResourceAttrPair(testDataSourceName, "peer_code.0.transport_tunnel_path", "nsxt_policy_ipsec_vpn_session.test", "path"),
+					resource.TestCheckResourceAttrSet(testDataSourceName, "peer_code.0.peer_code"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNsxtPolicyL2VpnSessionPeerConfigReadTemplate(name string) string {
+	return testAccNsxtPolicyIPSecVpnServicePrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_service" "test" {
+  display_name = "%s"
+  gateway_path = nsxt_policy_tier0_gateway.test.path
+}
+
+resource "nsxt_policy_l2vpn_service" "test" {
+  display_name = "%s"
+  gateway_path = nsxt_policy_tier0_gateway.test.path
+  mode         = "SERVER"
+}
+
+resource "nsxt_policy_ipsec_vpn_local_endpoint" "test" {
+  display_name  = "%s"
+  service_path  = nsxt_policy_ipsec_vpn_service.test.path
+  local_address = "20.20.0.10"
+}
+
+resource "nsxt_policy_ipsec_vpn_session" "test" {
+  display_name        = "%s"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "RouteBasedIPSecVpnSession"
+  peer_address        = "18.18.18.19"
+  peer_id             = "18.18.18.19"
+  psk                 = "secret1"
+
+  tunnel_interface {
+    ip_subnet {
+      ip_addresses  = ["169.254.152.2"]
+      prefix_length = 30
+    }
+  }
+}
+
+resource "nsxt_policy_l2vpn_session" "test" {
+  display_name      = "%s"
+  service_path      = nsxt_policy_l2vpn_service.test.path
+  transport_tunnels = [nsxt_policy_ipsec_vpn_session.test.path]
+}
+
+data "nsxt_policy_l2vpn_session_peer_config" "test" {
+  session_path = nsxt_policy_l2vpn_session.test.path
+}`, name, name, name, name, name)
+}
@@ -246,6 +246,11 @@ func Provider() *schema.Provider {
 			"nsxt_policy_ipsec_vpn_ike_profile":     dataSourceNsxtPolicyIPSecVpnIkeProfile(),
 			"nsxt_policy_ipsec_vpn_tunnel_profile":  dataSourceNsxtPolicyIpsecVpnTunnelProfile(),
 			"nsxt_policy_ipsec_vpn_local_endpoint":  dataSourceNsxtPolicyIPSecVpnLocalEndpoint(),
+			"nsxt_policy_ipsec_vpn_dpd_profile":     dataSourceNsxtPolicyIpsecVpnDpdProfile(),
+			"nsxt_policy_ipsec_vpn_session_status":  dataSourceNsxtPolicyIPSecVpnSessionStatus(),
+			"nsxt_policy_ipsec_vpn_sessions":        dataSourceNsxtPolicyIPSecVpnSessions(),
+			"nsxt_policy_ipsec_vpn_peer_config":     dataSourceNsxtPolicyIPSecVpnPeerConfig(),
+			"nsxt_policy_l2vpn_session_peer_config": dataSourceNsxtPolicyL2VpnSessionPeerConfig(),
 			"nsxt_policy_segment":                   dataSourceNsxtPolicySegment(),
 		},
 
@@ -357,8 +362,14 @@ func Provider() *schema.Provider {
 			"nsxt_policy_ospf_area":                        resourceNsxtPolicyOspfArea(),
 			"nsxt_policy_ipsec_vpn_ike_profile":            resourceNsxtPolicyIpsecVpnIkeProfile(),
 			"nsxt_policy_ipsec_vpn_tunnel_profile":         resourceNsxtPolicyIpsecVpnTunnelProfile(),
+			"nsxt_policy_ipsec_vpn_dpd_profile":            resourceNsxtPolicyIpsecVpnDpdProfile(),
 			"nsxt_policy_ipsec_vpn_session":                resourceNsxtPolicyIPSecVpnSession(),
+			"nsxt_policy_ipsec_vpn_service":                resourceNsxtPolicyIPSecVpnService(),
+			"nsxt_policy_ipsec_vpn_local_endpoint":         resourceNsxtPolicyIPSecVpnLocalEndpoint(),
+			"nsxt_policy_l2vpn_service":                    resourceNsxtPolicyL2VpnService(),
 			"nsxt_policy_l2vpn_session":                    resourceNsxtPolicyL2VPNSession(),
+			"nsxt_policy_l3vpn_context":                    resourceNsxtPolicyL3VpnContext(),
+			"nsxt_policy_l3vpn":                            resourceNsxtPolicyL3Vpn(),
 		},
 
 		ConfigureFunc: providerConfigure,
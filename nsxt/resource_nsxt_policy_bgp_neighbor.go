@@ -4,6 +4,7 @@
 package nsxt
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -37,6 +38,7 @@ func resourceNsxtPolicyBgpNeighbor() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceNsxtPolicyBgpNeighborImport,
 		},
+		CustomizeDiff: resourceNsxtPolicyBgpNeighborSourceVtiDiff,
 
 		Schema: map[string]*schema.Schema{
 			"nsx_id":       getNsxIDSchema(),
@@ -138,6 +140,23 @@ func resourceNsxtPolicyBgpNeighbor() *schema.Resource {
 					Type:         schema.TypeString,
 					ValidateFunc: validateSingleIP(),
 				},
+				ConflictsWith:    []string{"source_vti_path"},
+				DiffSuppressFunc: resourceNsxtPolicyBgpNeighborSourceAddressesDiffSuppress,
+			},
+			"source_vti_path": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Policy path of IPSec VPN tunnel interface to derive source addresses from",
+				ValidateFunc:  validatePolicyPath(),
+				ConflictsWith: []string{"source_addresses"},
+			},
+			"vti_source_addresses": {
+				Type:        schema.TypeList,
+				Description: "Source IP Addresses for BGP peering as derived from source_vti_path",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 			"route_filtering": {
 				Type:        schema.TypeList,
@@ -300,6 +319,73 @@ func resourceNsxtPolicyBgpNeighborResourceDataToStruct(d *schema.ResourceData, i
 	return neighborStruct, nil
 }
 
+func resourceNsxtPolicyBgpNeighborGetVtiSourceAddresses(connector *client.RestConnector, t0ID string, vtiPath string, neighborAddress string, isGlobalManager bool) ([]string, error) {
+	if isGlobalManager {
+		return nil, fmt.Errorf("source_vti_path is not supported with NSX Global Manager")
+	}
+
+	servicePath, _, vtiID := parseIPSecVpnTunnelInterfacePolicyPath(vtiPath)
+	if vtiID == "" {
+		return nil, fmt.Errorf("Invalid source_vti_path %s", vtiPath)
+	}
+	isT0, gwID, _, _ := parseIPSecVpnServicePolicyPath(servicePath)
+	if !isT0 || gwID != t0ID {
+		return nil, fmt.Errorf("Tunnel interface %s does not belong to Tier-0 Gateway %s", vtiPath, t0ID)
+	}
+
+	vti, err := getIPSecVpnTunnelInterfaceFromPath(connector, vtiPath)
+	if err != nil {
+		return nil, logAPIError(fmt.Sprintf("Error retrieving tunnel interface %s", vtiPath), err)
+	}
+	if vti == nil {
+		return nil, fmt.Errorf("Tunnel interface %s was not found", vtiPath)
+	}
+
+	sourceAddresses := getIPSecVpnTunnelInterfaceSourceAddresses(vti, neighborAddress)
+	if len(sourceAddresses) == 0 {
+		return nil, fmt.Errorf("neighbor_address %s is not within subnets of tunnel interface %s", neighborAddress, vtiPath)
+	}
+
+	return sourceAddresses, nil
+}
+
+func resourceNsxtPolicyBgpNeighborSourceAddressesEqual(expected []string, actual []string) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+	addresses := make(map[string]bool)
+	for _, address := range actual {
+		addresses[address] = true
+	}
+	for _, address := range expected {
+		if !addresses[address] {
+			return false
+		}
+	}
+	return true
+}
+
+// With source_vti_path set, source_addresses hold addresses read back from NSX
+func resourceNsxtPolicyBgpNeighborSourceAddressesDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return d.Get("source_vti_path").(string) != ""
+}
+
+// Source addresses on NSX that differ from addresses derived from tunnel interface
+// are re-applied on next apply
+func resourceNsxtPolicyBgpNeighborSourceVtiDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || d.Get("source_vti_path").(string) == "" || d.HasChange("source_vti_path") {
+		return nil
+	}
+
+	sourceAddresses, _ := d.GetChange("source_addresses")
+	vtiSourceAddresses, _ := d.GetChange("vti_source_addresses")
+	if resourceNsxtPolicyBgpNeighborSourceAddressesEqual(interface2StringList(vtiSourceAddresses.([]interface{})), interface2StringList(sourceAddresses.([]interface{}))) {
+		return nil
+	}
+
+	return d.SetNewComputed("vti_source_addresses")
+}
+
 func resourceNsxtPolicyBgpNeighborConvertAndPatch(id string, d *schema.ResourceData, m interface{}) error {
 	bgpPath := d.Get("bgp_path").(string)
 	t0ID, serviceID := resourceNsxtPolicyBgpNeighborParseIDs(bgpPath)
@@ -313,6 +399,15 @@ func resourceNsxtPolicyBgpNeighborConvertAndPatch(id string, d *schema.ResourceD
 	}
 
 	connector := getPolicyConnector(m)
+	vtiPath := d.Get("source_vti_path").(string)
+	if vtiPath != "" {
+		sourceAddresses, err := resourceNsxtPolicyBgpNeighborGetVtiSourceAddresses(connector, t0ID, vtiPath, *obj.NeighborAddress, isPolicyGlobalManager(m))
+		if err != nil {
+			return err
+		}
+		obj.SourceAddresses = sourceAddresses
+	}
+
 	// Create the resource using PATCH
 	log.Printf("[INFO] Creating BgpNeighbor with ID %s", id)
 	if isPolicyGlobalManager(m) {
@@ -418,6 +513,21 @@ func resourceNsxtPolicyBgpNeighborRead(d *schema.ResourceData, m interface{}) er
 	d.Set("neighbor_address", obj.NeighborAddress)
 	d.Set("remote_as_num", obj.RemoteAsNum)
 	d.Set("source_addresses", obj.SourceAddresses)
+	// source addresses are derived from tunnel interface if source_vti_path is set
+	vtiPath := d.Get("source_vti_path").(string)
+	if vtiPath == "" {
+		d.Set("vti_source_addresses", nil)
+	} else if !isPolicyGlobalManager(m) {
+		vti, err := getIPSecVpnTunnelInterfaceFromPath(connector, vtiPath)
+		if err != nil && !isNotFoundError(err) {
+			return logAPIError(fmt.Sprintf("Error retrieving tunnel interface %s", vtiPath), err)
+		}
+		if vti == nil {
+			log.Printf("[WARNING] Tunnel interface %s of BgpNeighbor %s was not found", vtiPath, id)
+		} else {
+			d.Set("vti_source_addresses", getIPSecVpnTunnelInterfaceSourceAddresses(vti, *obj.NeighborAddress))
+		}
+	}
 
 	var bfdConfigs []interface{}
 	if obj.Bfd != nil {
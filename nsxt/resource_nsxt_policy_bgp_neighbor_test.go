@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services/bgp"
 )
 
 var accTestPolicyBgpNeighborConfigCreateAttributes = map[string]string{
@@ -299,6 +300,43 @@ func TestAccResourceNsxtPolicyBgpNeighbor_subConfigPrefixList(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtPolicyBgpNeighbor_sourceVti(t *testing.T) {
+	testResourceName := "nsxt_policy_bgp_neighbor.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyBgpNeighborCheckDestroy(state, accTestPolicyBgpNeighborConfigCreateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyBgpNeighborSourceVti(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyBgpNeighborExists(testResourceName),
+					resource.TestCheckResourceAttrPair(testResourceName, "source_vti_path", "nsxt_policy_ipsec_vpn_session.test", "tunnel_interface.0.path"),
+					resource.TestCheckResourceAttrPair(testResourceName, "neighbor_address", "nsxt_policy_ipsec_vpn_session.test", "tunnel_interface.0.peer_ip_addresses.0"),
+					resource.TestCheckResourceAttr(testResourceName, "source_addresses.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "vti_source_addresses.#", "1"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					// Remove source addresses on NSX to verify drift is detected
+					testAccNsxtPolicyBgpNeighborClearSourceAddresses(testResourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccNsxtPolicyBgpNeighborSourceVti(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyBgpNeighborExists(testResourceName),
+					resource.TestCheckResourceAttrPair(testResourceName, "source_vti_path", "nsxt_policy_ipsec_vpn_session.test", "tunnel_interface.0.path"),
+					resource.TestCheckResourceAttr(testResourceName, "vti_source_addresses.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "source_addresses.#", "1"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceNsxtPolicyBgpNeighbor_importBasic(t *testing.T) {
 	name := getAccTestResourceName()
 	testResourceName := "nsxt_policy_bgp_neighbor.test"
@@ -370,6 +408,27 @@ func testAccNsxtPolicyBgpNeighborExists(resourceName string) resource.TestCheckF
 	}
 }
 
+func testAccNsxtPolicyBgpNeighborClearSourceAddresses(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Policy BgpNeighbor resource %s not found in resources", resourceName)
+		}
+
+		t0ID, serviceID := resourceNsxtPolicyBgpNeighborParseIDs(rs.Primary.Attributes["bgp_path"])
+		client := bgp.NewDefaultNeighborsClient(connector)
+		obj, err := client.Get(t0ID, serviceID, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		obj.SourceAddresses = nil
+		_, err = client.Update(t0ID, serviceID, rs.Primary.ID, obj, nil)
+		return err
+	}
+}
+
 func testAccNsxtPolicyBgpNeighborCheckDestroy(state *terraform.State, displayName string) error {
 	connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
 	for _, rs := range state.RootModule().Resources {
@@ -791,3 +850,14 @@ data "nsxt_policy_realization_info" "realization_info" {
   site_path = data.nsxt_policy_site.test.path
 }`, subnet, attrMap["display_name"], attrMap["description"], attrMap["allow_as_in"], attrMap["graceful_restart_mode"], attrMap["hold_down_time"], attrMap["keep_alive_time"], attrMap["maximum_hop_limit"], attrMap["neighbor_address"], attrMap["remote_as_num"], attrMap["password"])
 }
+
+func testAccNsxtPolicyBgpNeighborSourceVti() string {
+	return testAccNsxtPolicyIPSecVpnSessionMinimalistic(true) + fmt.Sprintf(`
+resource "nsxt_policy_bgp_neighbor" "test" {
+  bgp_path         = nsxt_policy_tier0_gateway.test.bgp_config.0.path
+  display_name     = "%s"
+  neighbor_address = nsxt_policy_ipsec_vpn_session.test.tunnel_interface.0.peer_ip_addresses.0
+  remote_as_num    = "%s"
+  source_vti_path  = nsxt_policy_ipsec_vpn_session.test.tunnel_interface.0.path
+}`, accTestPolicyBgpNeighborConfigCreateAttributes["display_name"], accTestPolicyBgpNeighborConfigCreateAttributes["remote_as_num"])
+}
@@ -20,7 +20,8 @@ func resourceNsxtPolicyFixedSegment() *schema.Resource {
 			State: nsxtGatewayResourceImporter,
 		},
 
-		Schema: getPolicyCommonSegmentSchema(false, true),
+		Schema:        getPolicyCommonSegmentSchema(false, true),
+		CustomizeDiff: nsxtPolicySegmentL2ExtensionValidate,
 	}
 }
 
@@ -0,0 +1,206 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/global_infra"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+var IPSecVpnDpdProfileDpdProbeModeValues = []string{
+	model.IPSecVpnDpdProfile_DPD_PROBE_MODE_PERIODIC,
+	model.IPSecVpnDpdProfile_DPD_PROBE_MODE_ON_DEMAND,
+}
+
+func resourceNsxtPolicyIpsecVpnDpdProfile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNsxtPolicyIpsecVpnDpdProfileCreate,
+		Read:   resourceNsxtPolicyIpsecVpnDpdProfileRead,
+		Update: resourceNsxtPolicyIpsecVpnDpdProfileUpdate,
+		Delete: resourceNsxtPolicyIpsecVpnDpdProfileDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"nsx_id":       getNsxIDSchema(),
+			"path":         getPathSchema(),
+			"display_name": getDisplayNameSchema(),
+			"description":  getDescriptionSchema(),
+			"revision":     getRevisionSchema(),
+			"tag":          getTagsSchema(),
+			"dpd_probe_mode": {
+				Type:         schema.TypeString,
+				Description:  "DPD probe mode. PERIODIC mode sends probes at regular intervals, ON_DEMAND mode sends probes only when there is outgoing traffic and no incoming traffic",
+				Optional:     true,
+				Default:      model.IPSecVpnDpdProfile_DPD_PROBE_MODE_PERIODIC,
+				ValidateFunc: validation.StringInSlice(IPSecVpnDpdProfileDpdProbeModeValues, false),
+			},
+			"dpd_probe_interval": {
+				Type:         schema.TypeInt,
+				Description:  "Interval in seconds between DPD probes. Allowed range is 3-360 for PERIODIC mode and 1-10 for ON_DEMAND mode",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 360),
+			},
+			"retry_count": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of DPD retry attempts",
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable/Disable dead peer detection",
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+func getIPSecVpnDpdProfilesClient(connector *client.RestConnector, isGlobalManager bool) infra.IpsecVpnDpdProfilesClient {
+	if isGlobalManager {
+		return global_infra.NewDefaultIpsecVpnDpdProfilesClient(connector)
+	}
+	return infra.NewDefaultIpsecVpnDpdProfilesClient(connector)
+}
+
+func resourceNsxtPolicyIpsecVpnDpdProfileExists(id string, connector *client.RestConnector, isGlobalManager bool) (bool, error) {
+	client := getIPSecVpnDpdProfilesClient(connector, isGlobalManager)
+	_, err := client.Get(id)
+	if err == nil {
+		return true, nil
+	}
+
+	if isNotFoundError(err) {
+		return false, nil
+	}
+
+	return false, logAPIError("Error retrieving resource", err)
+}
+
+func policyIpsecVpnDpdProfileFromSchema(d *schema.ResourceData) model.IPSecVpnDpdProfile {
+	displayName := d.Get("display_name").(string)
+	description := d.Get("description").(string)
+	tags := getPolicyTagsFromSchema(d)
+	dpdProbeMode := d.Get("dpd_probe_mode").(string)
+	retryCount := int64(d.Get("retry_count").(int))
+	enabled := d.Get("enabled").(bool)
+
+	obj := model.IPSecVpnDpdProfile{
+		DisplayName:  &displayName,
+		Description:  &description,
+		Tags:         tags,
+		DpdProbeMode: &dpdProbeMode,
+		RetryCount:   &retryCount,
+		Enabled:      &enabled,
+	}
+
+	dpdProbeInterval := int64(d.Get("dpd_probe_interval").(int))
+	if dpdProbeInterval > 0 {
+		obj.DpdProbeInterval = &dpdProbeInterval
+	}
+
+	return obj
+}
+
+func resourceNsxtPolicyIpsecVpnDpdProfileCreate(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	// Initialize resource Id and verify this ID is not yet used
+	id, err := getOrGenerateID(d, m, resourceNsxtPolicyIpsecVpnDpdProfileExists)
+	if err != nil {
+		return err
+	}
+
+	obj := policyIpsecVpnDpdProfileFromSchema(d)
+
+	// Create the resource using PATCH
+	log.Printf("[INFO] Creating IpsecVpnDpdProfile with ID %s", id)
+	client := getIPSecVpnDpdProfilesClient(connector, isPolicyGlobalManager(m))
+	err = client.Patch(id, obj)
+	if err != nil {
+		return handleCreateError("IpsecVpnDpdProfile", id, err)
+	}
+
+	d.SetId(id)
+	d.Set("nsx_id", id)
+
+	return resourceNsxtPolicyIpsecVpnDpdProfileRead(d, m)
+}
+
+func resourceNsxtPolicyIpsecVpnDpdProfileRead(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	if id == "" {
+		return fmt.Errorf("Error obtaining IpsecVpnDpdProfile ID")
+	}
+
+	client := getIPSecVpnDpdProfilesClient(connector, isPolicyGlobalManager(m))
+	obj, err := client.Get(id)
+	if err != nil {
+		return handleReadError(d, "IpsecVpnDpdProfile", id, err)
+	}
+
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	setPolicyTagsInSchema(d, obj.Tags)
+	d.Set("nsx_id", id)
+	d.Set("path", obj.Path)
+	d.Set("revision", obj.Revision)
+	d.Set("dpd_probe_mode", obj.DpdProbeMode)
+	d.Set("dpd_probe_interval", obj.DpdProbeInterval)
+	d.Set("retry_count", obj.RetryCount)
+	d.Set("enabled", obj.Enabled)
+
+	return nil
+}
+
+func resourceNsxtPolicyIpsecVpnDpdProfileUpdate(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	if id == "" {
+		return fmt.Errorf("Error obtaining IpsecVpnDpdProfile ID")
+	}
+
+	obj := policyIpsecVpnDpdProfileFromSchema(d)
+	revision := int64(d.Get("revision").(int))
+	obj.Revision = &revision
+
+	client := getIPSecVpnDpdProfilesClient(connector, isPolicyGlobalManager(m))
+	err := client.Patch(id, obj)
+	if err != nil {
+		return handleUpdateError("IpsecVpnDpdProfile", id, err)
+	}
+
+	return resourceNsxtPolicyIpsecVpnDpdProfileRead(d, m)
+}
+
+func resourceNsxtPolicyIpsecVpnDpdProfileDelete(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	if id == "" {
+		return fmt.Errorf("Error obtaining IpsecVpnDpdProfile ID")
+	}
+
+	client := getIPSecVpnDpdProfilesClient(connector, isPolicyGlobalManager(m))
+	err := client.Delete(id)
+	if err != nil {
+		return handleDeleteError("IpsecVpnDpdProfile", id, err)
+	}
+
+	return nil
+}
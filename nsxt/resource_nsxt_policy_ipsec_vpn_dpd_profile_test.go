@@ -0,0 +1,190 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var accTestPolicyIpsecVpnDpdProfileCreateAttributes = map[string]string{
+	"display_name":       getAccTestResourceName(),
+	"description":        "terraform created",
+	"dpd_probe_mode":     "PERIODIC",
+	"dpd_probe_interval": "120",
+	"retry_count":        "20",
+	"enabled":            "true",
+}
+
+var accTestPolicyIpsecVpnDpdProfileUpdateAttributes = map[string]string{
+	"display_name":       getAccTestResourceName(),
+	"description":        "terraform updated",
+	"dpd_probe_mode":     "ON_DEMAND",
+	"dpd_probe_interval": "5",
+	"retry_count":        "3",
+	"enabled":            "false",
+}
+
+func TestAccResourceNsxtPolicyIpsecVpnDpdProfile_basic(t *testing.T) {
+	testResourceName := "nsxt_policy_ipsec_vpn_dpd_profile.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIpsecVpnDpdProfileCheckDestroy(state, accTestPolicyIpsecVpnDpdProfileUpdateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIpsecVpnDpdProfileTemplate(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIpsecVpnDpdProfileExists(accTestPolicyIpsecVpnDpdProfileCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyIpsecVpnDpdProfileCreateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyIpsecVpnDpdProfileCreateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "dpd_probe_mode", accTestPolicyIpsecVpnDpdProfileCreateAttributes["dpd_probe_mode"]),
+					resource.TestCheckResourceAttr(testResourceName, "dpd_probe_interval", accTestPolicyIpsecVpnDpdProfileCreateAttributes["dpd_probe_interval"]),
+					resource.TestCheckResourceAttr(testResourceName, "retry_count", accTestPolicyIpsecVpnDpdProfileCreateAttributes["retry_count"]),
+					resource.TestCheckResourceAttr(testResourceName, "enabled", accTestPolicyIpsecVpnDpdProfileCreateAttributes["enabled"]),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIpsecVpnDpdProfileTemplate(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIpsecVpnDpdProfileExists(accTestPolicyIpsecVpnDpdProfileUpdateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyIpsecVpnDpdProfileUpdateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyIpsecVpnDpdProfileUpdateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "dpd_probe_mode", accTestPolicyIpsecVpnDpdProfileUpdateAttributes["dpd_probe_mode"]),
+					resource.TestCheckResourceAttr(testResourceName, "dpd_probe_interval", accTestPolicyIpsecVpnDpdProfileUpdateAttributes["dpd_probe_interval"]),
+					resource.TestCheckResourceAttr(testResourceName, "retry_count", accTestPolicyIpsecVpnDpdProfileUpdateAttributes["retry_count"]),
+					resource.TestCheckResourceAttr(testResourceName, "enabled", accTestPolicyIpsecVpnDpdProfileUpdateAttributes["enabled"]),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIpsecVpnDpdProfileMinimalistic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIpsecVpnDpdProfileExists(accTestPolicyIpsecVpnDpdProfileUpdateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "description", ""),
+					resource.TestCheckResourceAttr(testResourceName, "dpd_probe_mode", "PERIODIC"),
+					resource.TestCheckResourceAttrSet(testResourceName, "dpd_probe_interval"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIpsecVpnDpdProfile_importBasic(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_policy_ipsec_vpn_dpd_profile.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIpsecVpnDpdProfileCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIpsecVpnDpdProfileMinimalistic(),
+			},
+			{
+				ResourceName:      testResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNsxtPolicyIpsecVpnDpdProfileExists(displayName string, resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+
+		connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Policy IpsecVpnDpdProfile resource %s not found in resources", resourceName)
+		}
+
+		resourceID := rs.Primary.ID
+		if resourceID == "" {
+			return fmt.Errorf("Policy IpsecVpnDpdProfile resource ID not set in resources")
+		}
+
+		exists, err := resourceNsxtPolicyIpsecVpnDpdProfileExists(resourceID, connector, testAccIsGlobalManager())
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("Policy IpsecVpnDpdProfile %s does not exist", resourceID)
+		}
+
+		return nil
+	}
+}
+
+func testAccNsxtPolicyIpsecVpnDpdProfileCheckDestroy(state *terraform.State, displayName string) error {
+	connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+	for _, rs := range state.RootModule().Resources {
+
+		if rs.Type != "nsxt_policy_ipsec_vpn_dpd_profile" {
+			continue
+		}
+
+		resourceID := rs.Primary.Attributes["id"]
+		exists, err := resourceNsxtPolicyIpsecVpnDpdProfileExists(resourceID, connector, testAccIsGlobalManager())
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return fmt.Errorf("Policy IpsecVpnDpdProfile %s still exists", displayName)
+		}
+	}
+	return nil
+}
+
+func testAccNsxtPolicyIpsecVpnDpdProfileTemplate(createFlow bool) string {
+	var attrMap map[string]string
+	if createFlow {
+		attrMap = accTestPolicyIpsecVpnDpdProfileCreateAttributes
+	} else {
+		attrMap = accTestPolicyIpsecVpnDpdProfileUpdateAttributes
+	}
+	return fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_dpd_profile" "test" {
+  display_name       = "%s"
+  description        = "%s"
+  dpd_probe_mode     = "%s"
+  dpd_probe_interval = %s
+  retry_count        = %s
+  enabled            = %s
+
+  tag {
+    scope = "scope1"
+    tag   = "tag1"
+  }
+}`, attrMap["display_name"], attrMap["description"], attrMap["dpd_probe_mode"], attrMap["dpd_probe_interval"], attrMap["retry_count"], attrMap["enabled"])
+}
+
+func testAccNsxtPolicyIpsecVpnDpdProfileMinimalistic() string {
+	return fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_dpd_profile" "test" {
+  display_name = "%s"
+}`, accTestPolicyIpsecVpnDpdProfileUpdateAttributes["display_name"])
+}
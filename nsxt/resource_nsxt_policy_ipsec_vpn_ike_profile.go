@@ -4,12 +4,15 @@
 package nsxt
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/global_infra"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 )
@@ -19,7 +22,7 @@ var IPSecVpnIkeProfileEncryptionAlgorithms = []string{
 	model.IPSecVpnIkeProfile_ENCRYPTION_ALGORITHMS_256,
 	model.IPSecVpnIkeProfile_ENCRYPTION_ALGORITHMS_GCM_128,
 	model.IPSecVpnIkeProfile_ENCRYPTION_ALGORITHMS_GCM_192,
-	model.IPSecVpnIkeProfile_ENCRYPTION_ALGORITHMS_256,
+	model.IPSecVpnIkeProfile_ENCRYPTION_ALGORITHMS_GCM_256,
 }
 
 var IPSecVpnIkeProfileDigestAlgorithms = []string{
@@ -54,6 +57,7 @@ func resourceNsxtPolicyIpsecVpnIkeProfile() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceNsxtPolicyIpsecVpnIkeProfileValidate,
 
 		Schema: map[string]*schema.Schema{
 			"nsx_id":       getNsxIDSchema(),
@@ -77,6 +81,7 @@ func resourceNsxtPolicyIpsecVpnIkeProfile() *schema.Resource {
 					ValidateFunc: validation.StringInSlice(IPSecVpnIkeProfileEncryptionAlgorithms, false),
 				},
 				Optional: true,
+				Computed: true,
 			},
 			"digest_algorithms": {
 				Type:        schema.TypeSet,
@@ -86,6 +91,7 @@ func resourceNsxtPolicyIpsecVpnIkeProfile() *schema.Resource {
 					ValidateFunc: validation.StringInSlice(IPSecVpnIkeProfileDigestAlgorithms, false),
 				},
 				Optional: true,
+				Computed: true,
 			},
 			"dh_groups": {
 				Type:        schema.TypeSet,
@@ -95,15 +101,30 @@ func resourceNsxtPolicyIpsecVpnIkeProfile() *schema.Resource {
 					ValidateFunc: validation.StringInSlice(IPSecVpnIkeProfileDhGroups, false),
 				},
 				Optional: true,
+				Computed: true,
+			},
+			"sa_life_time": {
+				Type:         schema.TypeInt,
+				Description:  "Life time for security association in seconds",
+				Optional:     true,
+				Default:      86400,
+				ValidateFunc: validation.IntBetween(21600, 31536000),
 			},
 		},
 	}
 }
 
+func getIPSecVpnIkeProfilesClient(connector *client.RestConnector, isGlobalManager bool) infra.IpsecVpnIkeProfilesClient {
+	if isGlobalManager {
+		return global_infra.NewDefaultIpsecVpnIkeProfilesClient(connector)
+	}
+	return infra.NewDefaultIpsecVpnIkeProfilesClient(connector)
+}
+
 func resourceNsxtPolicyIpsecVpnIkeProfileExists(id string, connector *client.RestConnector, isGlobalManager bool) (bool, error) {
 	var err error
 
-	client := infra.NewDefaultIpsecVpnIkeProfilesClient(connector)
+	client := getIPSecVpnIkeProfilesClient(connector, isGlobalManager)
 	_, err = client.Get(id)
 
 	if err == nil {
@@ -117,35 +138,75 @@ func resourceNsxtPolicyIpsecVpnIkeProfileExists(id string, connector *client.Res
 	return false, logAPIError("Error retrieving resource", err)
 }
 
-func resourceNsxtPolicyIpsecVpnIkeProfileCreate(d *schema.ResourceData, m interface{}) error {
-	connector := getPolicyConnector(m)
+func isIPSecVpnIkeProfileGcmAlgorithm(algorithm string) bool {
+	return strings.HasPrefix(algorithm, "AES_GCM_")
+}
 
-	// Initialize resource Id and verify this ID is not yet used
-	id, err := getOrGenerateID(d, m, resourceNsxtPolicyIpsecVpnIkeProfileExists)
-	if err != nil {
-		return err
+func resourceNsxtPolicyIpsecVpnIkeProfileValidate(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	ikeVersion := d.Get("ike_version").(string)
+	encryptionAlgorithms := interface2StringList(d.Get("encryption_algorithms").(*schema.Set).List())
+	digestAlgorithms := interface2StringList(d.Get("digest_algorithms").(*schema.Set).List())
+
+	var gcmAlgorithms []string
+	for _, algorithm := range encryptionAlgorithms {
+		if isIPSecVpnIkeProfileGcmAlgorithm(algorithm) {
+			gcmAlgorithms = append(gcmAlgorithms, algorithm)
+		}
+	}
+
+	if len(gcmAlgorithms) == 0 {
+		return nil
+	}
+
+	if len(gcmAlgorithms) != len(encryptionAlgorithms) {
+		return fmt.Errorf("GCM encryption algorithms %v can not be combined with non-GCM encryption algorithms in the same IKE profile", gcmAlgorithms)
+	}
+
+	if ikeVersion != model.IPSecVpnIkeProfile_IKE_VERSION_V2 {
+		return fmt.Errorf("GCM encryption algorithms %v are only supported with ike_version %s, got %s", gcmAlgorithms, model.IPSecVpnIkeProfile_IKE_VERSION_V2, ikeVersion)
+	}
+
+	if len(digestAlgorithms) == 0 {
+		return fmt.Errorf("digest_algorithms must be specified with GCM encryption algorithms %v, digest is used as pseudo-random function in %s", gcmAlgorithms, ikeVersion)
 	}
 
+	return nil
+}
+
+func policyIpsecVpnIkeProfileFromSchema(d *schema.ResourceData) model.IPSecVpnIkeProfile {
 	displayName := d.Get("display_name").(string)
 	description := d.Get("description").(string)
+	tags := getPolicyTagsFromSchema(d)
 	ikeVersion := d.Get("ike_version").(string)
-	DhGroups := getStringListFromSchemaSet(d, "dh_groups")
-	DigestAlgorithms := getStringListFromSchemaSet(d, "digest_algorithms")
-	EncryptionAlgorithms := getStringListFromSchemaSet(d, "encryption_algorithms")
+	saLifeTime := int64(d.Get("sa_life_time").(int))
 
-	obj := model.IPSecVpnIkeProfile{
+	return model.IPSecVpnIkeProfile{
 		DisplayName:          &displayName,
 		Description:          &description,
+		Tags:                 tags,
 		IkeVersion:           &ikeVersion,
-		DhGroups:             DhGroups,
-		DigestAlgorithms:     DigestAlgorithms,
-		EncryptionAlgorithms: EncryptionAlgorithms,
+		DhGroups:             getStringListFromSchemaSet(d, "dh_groups"),
+		DigestAlgorithms:     getStringListFromSchemaSet(d, "digest_algorithms"),
+		EncryptionAlgorithms: getStringListFromSchemaSet(d, "encryption_algorithms"),
+		SaLifeTime:           &saLifeTime,
+	}
+}
+
+func resourceNsxtPolicyIpsecVpnIkeProfileCreate(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	// Initialize resource Id and verify this ID is not yet used
+	id, err := getOrGenerateID(d, m, resourceNsxtPolicyIpsecVpnIkeProfileExists)
+	if err != nil {
+		return err
 	}
 
+	obj := policyIpsecVpnIkeProfileFromSchema(d)
+
 	// Create the resource using PATCH
 	log.Printf("[INFO] Creating IpsecVpnIkeProfile with ID %s", id)
 
-	client := infra.NewDefaultIpsecVpnIkeProfilesClient(connector)
+	client := getIPSecVpnIkeProfilesClient(connector, isPolicyGlobalManager(m))
 	err = client.Patch(id, obj)
 
 	if err != nil {
@@ -166,7 +227,7 @@ func resourceNsxtPolicyIpsecVpnIkeProfileRead(d *schema.ResourceData, m interfac
 	}
 
 	var obj model.IPSecVpnIkeProfile
-	client := infra.NewDefaultIpsecVpnIkeProfilesClient(connector)
+	client := getIPSecVpnIkeProfilesClient(connector, isPolicyGlobalManager(m))
 	var err error
 	obj, err = client.Get(id)
 	if err != nil {
@@ -178,6 +239,11 @@ func resourceNsxtPolicyIpsecVpnIkeProfileRead(d *schema.ResourceData, m interfac
 	d.Set("nsx_id", id)
 	d.Set("path", obj.Path)
 	d.Set("revision", obj.Revision)
+	d.Set("ike_version", obj.IkeVersion)
+	d.Set("encryption_algorithms", obj.EncryptionAlgorithms)
+	d.Set("digest_algorithms", obj.DigestAlgorithms)
+	d.Set("dh_groups", obj.DhGroups)
+	d.Set("sa_life_time", obj.SaLifeTime)
 	return nil
 }
 
@@ -188,22 +254,12 @@ func resourceNsxtPolicyIpsecVpnIkeProfileUpdate(d *schema.ResourceData, m interf
 	if id == "" {
 		return fmt.Errorf("Error obtaining IpsecVpnIkeProfile ID")
 	}
-	displayName := d.Get("display_name").(string)
-	description := d.Get("description").(string)
-	ikeVersion := d.Get("ike_version").(string)
-	DhGroups := getStringListFromSchemaSet(d, "dh_groups")
-	DigestAlgorithms := getStringListFromSchemaSet(d, "digest_algorithms")
-	EncryptionAlgorithms := getStringListFromSchemaSet(d, "encryption_algorithms")
-	obj := model.IPSecVpnIkeProfile{
-		DisplayName:          &displayName,
-		Description:          &description,
-		IkeVersion:           &ikeVersion,
-		DhGroups:             DhGroups,
-		DigestAlgorithms:     DigestAlgorithms,
-		EncryptionAlgorithms: EncryptionAlgorithms,
-	}
+	obj := policyIpsecVpnIkeProfileFromSchema(d)
+	revision := int64(d.Get("revision").(int))
+	obj.Revision = &revision
+
 	var err error
-	client := infra.NewDefaultIpsecVpnIkeProfilesClient(connector)
+	client := getIPSecVpnIkeProfilesClient(connector, isPolicyGlobalManager(m))
 	err = client.Patch(id, obj)
 
 	if err != nil {
@@ -222,7 +278,7 @@ func resourceNsxtPolicyIpsecVpnIkeProfileDelete(d *schema.ResourceData, m interf
 
 	connector := getPolicyConnector(m)
 	var err error
-	client := infra.NewDefaultIpsecVpnIkeProfilesClient(connector)
+	client := getIPSecVpnIkeProfilesClient(connector, isPolicyGlobalManager(m))
 	err = client.Delete(id)
 
 	if err != nil {
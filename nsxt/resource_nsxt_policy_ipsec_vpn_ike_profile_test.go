@@ -0,0 +1,214 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var accTestPolicyIpsecVpnIkeProfileCreateAttributes = map[string]string{
+	"display_name":          getAccTestResourceName(),
+	"description":           "terraform created",
+	"ike_version":           "IKE_V2",
+	"encryption_algorithms": "AES_GCM_128",
+	"digest_algorithms":     "SHA2_256",
+	"dh_groups":             "GROUP14",
+	"sa_life_time":          "86400",
+}
+
+var accTestPolicyIpsecVpnIkeProfileUpdateAttributes = map[string]string{
+	"display_name":          getAccTestResourceName(),
+	"description":           "terraform updated",
+	"ike_version":           "IKE_FLEX",
+	"encryption_algorithms": "AES_256",
+	"digest_algorithms":     "SHA2_384",
+	"dh_groups":             "GROUP19",
+	"sa_life_time":          "43200",
+}
+
+func TestAccResourceNsxtPolicyIpsecVpnIkeProfile_basic(t *testing.T) {
+	testResourceName := "nsxt_policy_ipsec_vpn_ike_profile.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIpsecVpnIkeProfileCheckDestroy(state, accTestPolicyIpsecVpnIkeProfileUpdateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNsxtPolicyIpsecVpnIkeProfileInvalidTemplate("IKE_V1", "[\"SHA2_256\"]"),
+				ExpectError: regexp.MustCompile(`only supported with ike_version IKE_V2`),
+			},
+			{
+				Config:      testAccNsxtPolicyIpsecVpnIkeProfileInvalidTemplate("IKE_V2", "[]"),
+				ExpectError: regexp.MustCompile(`digest_algorithms must be specified`),
+			},
+			{
+				Config: testAccNsxtPolicyIpsecVpnIkeProfileTemplate(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIpsecVpnIkeProfileExists(accTestPolicyIpsecVpnIkeProfileCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyIpsecVpnIkeProfileCreateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyIpsecVpnIkeProfileCreateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "ike_version", accTestPolicyIpsecVpnIkeProfileCreateAttributes["ike_version"]),
+					resource.TestCheckResourceAttr(testResourceName, "encryption_algorithms.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "digest_algorithms.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "dh_groups.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "sa_life_time", accTestPolicyIpsecVpnIkeProfileCreateAttributes["sa_life_time"]),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIpsecVpnIkeProfileTemplate(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIpsecVpnIkeProfileExists(accTestPolicyIpsecVpnIkeProfileUpdateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyIpsecVpnIkeProfileUpdateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyIpsecVpnIkeProfileUpdateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "ike_version", accTestPolicyIpsecVpnIkeProfileUpdateAttributes["ike_version"]),
+					resource.TestCheckResourceAttr(testResourceName, "encryption_algorithms.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "digest_algorithms.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "dh_groups.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "sa_life_time", accTestPolicyIpsecVpnIkeProfileUpdateAttributes["sa_life_time"]),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIpsecVpnIkeProfileMinimalistic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIpsecVpnIkeProfileExists(accTestPolicyIpsecVpnIkeProfileUpdateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "description", ""),
+					resource.TestCheckResourceAttr(testResourceName, "ike_version", "IKE_V2"),
+					resource.TestCheckResourceAttr(testResourceName, "sa_life_time", "86400"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIpsecVpnIkeProfile_importBasic(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_policy_ipsec_vpn_ike_profile.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIpsecVpnIkeProfileCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIpsecVpnIkeProfileMinimalistic(),
+			},
+			{
+				ResourceName:      testResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNsxtPolicyIpsecVpnIkeProfileExists(displayName string, resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+
+		connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Policy IpsecVpnIkeProfile resource %s not found in resources", resourceName)
+		}
+
+		resourceID := rs.Primary.ID
+		if resourceID == "" {
+			return fmt.Errorf("Policy IpsecVpnIkeProfile resource ID not set in resources")
+		}
+
+		exists, err := resourceNsxtPolicyIpsecVpnIkeProfileExists(resourceID, connector, testAccIsGlobalManager())
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("Policy IpsecVpnIkeProfile %s does not exist", resourceID)
+		}
+
+		return nil
+	}
+}
+
+func testAccNsxtPolicyIpsecVpnIkeProfileCheckDestroy(state *terraform.State, displayName string) error {
+	connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+	for _, rs := range state.RootModule().Resources {
+
+		if rs.Type != "nsxt_policy_ipsec_vpn_ike_profile" {
+			continue
+		}
+
+		resourceID := rs.Primary.Attributes["id"]
+		exists, err := resourceNsxtPolicyIpsecVpnIkeProfileExists(resourceID, connector, testAccIsGlobalManager())
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return fmt.Errorf("Policy IpsecVpnIkeProfile %s still exists", displayName)
+		}
+	}
+	return nil
+}
+
+func testAccNsxtPolicyIpsecVpnIkeProfileTemplate(createFlow bool) string {
+	var attrMap map[string]string
+	if createFlow {
+		attrMap = accTestPolicyIpsecVpnIkeProfileCreateAttributes
+	} else {
+		attrMap = accTestPolicyIpsecVpnIkeProfileUpdateAttributes
+	}
+	return fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_ike_profile" "test" {
+  display_name          = "%s"
+  description           = "%s"
+  ike_version           = "%s"
+  encryption_algorithms = ["%s"]
+  digest_algorithms     = ["%s"]
+  dh_groups             = ["%s"]
+  sa_life_time          = %s
+
+  tag {
+    scope = "scope1"
+    tag   = "tag1"
+  }
+}`, attrMap["display_name"], attrMap["description"], attrMap["ike_version"], attrMap["encryption_algorithms"], attrMap["digest_algorithms"], attrMap["dh_groups"], attrMap["sa_life_time"])
+}
+
+func testAccNsxtPolicyIpsecVpnIkeProfileInvalidTemplate(ikeVersion string, digestAlgorithms string) string {
+	return fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_ike_profile" "test" {
+  display_name          = "%s"
+  ike_version           = "%s"
+  encryption_algorithms = ["AES_GCM_256"]
+  digest_algorithms     = %s
+}`, accTestPolicyIpsecVpnIkeProfileCreateAttributes["display_name"], ikeVersion, digestAlgorithms)
+}
+
+func testAccNsxtPolicyIpsecVpnIkeProfileMinimalistic() string {
+	return fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_ike_profile" "test" {
+  display_name = "%s"
+}`, accTestPolicyIpsecVpnIkeProfileUpdateAttributes["display_name"])
+}
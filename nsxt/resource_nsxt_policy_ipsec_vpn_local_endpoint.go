@@ -0,0 +1,278 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	gm_t0_ipsec_vpn_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/global_infra/tier_0s/locale_services/ipsec_vpn_services"
+	t0_ipsec_vpn_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services/ipsec_vpn_services"
+	t1_ipsec_vpn_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/locale_services/ipsec_vpn_services"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func resourceNsxtPolicyIPSecVpnLocalEndpoint() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNsxtPolicyIPSecVpnLocalEndpointCreate,
+		Read:   resourceNsxtPolicyIPSecVpnLocalEndpointRead,
+		Update: resourceNsxtPolicyIPSecVpnLocalEndpointUpdate,
+		Delete: resourceNsxtPolicyIPSecVpnLocalEndpointDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceNsxtPolicyIPSecVpnLocalEndpointImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"nsx_id":       getNsxIDSchema(),
+			"path":         getPathSchema(),
+			"display_name": getDisplayNameSchema(),
+			"description":  getDescriptionSchema(),
+			"revision":     getRevisionSchema(),
+			"tag":          getTagsSchema(),
+			"service_path": {
+				Type:         schema.TypeString,
+				Description:  "Policy path for IPSec VPN service",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateVpnServicePolicyPath("ipsec-vpn-services"),
+			},
+			"local_address": {
+				Type:         schema.TypeString,
+				Description:  "Local IPv4 IP address",
+				Required:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"local_id": {
+				Type:        schema.TypeString,
+				Description: "Local identifier. Defaults to local address if not specified",
+				Optional:    true,
+				Computed:    true,
+			},
+			"certificate_path": getPolicyPathSchema(false, false, "Policy path referencing site certificate, required for certificate based authentication"),
+			"trust_ca_paths": {
+				Type:        schema.TypeList,
+				Description: "List of policy paths referencing certificate authority (CA) to verify peer certificates",
+				Optional:    true,
+				Elem:        getElemPolicyPathSchema(),
+			},
+			"trust_crl_paths": {
+				Type:        schema.TypeList,
+				Description: "List of policy paths referencing certificate revocation list (CRL) to peer certificates",
+				Optional:    true,
+				Elem:        getElemPolicyPathSchema(),
+			},
+		},
+	}
+}
+
+func getIPSecVpnLocalEndpointsT0Client(connector *client.RestConnector, isGlobalManager bool) t0_ipsec_vpn_services.LocalEndpointsClient {
+	if isGlobalManager {
+		return gm_t0_ipsec_vpn_services.NewDefaultLocalEndpointsClient(connector)
+	}
+	return t0_ipsec_vpn_services.NewDefaultLocalEndpointsClient(connector)
+}
+
+func getNsxtPolicyIPSecVpnLocalEndpoint(connector *client.RestConnector, isGlobalManager bool, isT0 bool, gwID string, localeServiceID string, serviceID string, id string) (model.IPSecVpnLocalEndpoint, error) {
+	if isT0 {
+		client := getIPSecVpnLocalEndpointsT0Client(connector, isGlobalManager)
+		return client.Get(gwID, localeServiceID, serviceID, id)
+	}
+	client := t1_ipsec_vpn_services.NewDefaultLocalEndpointsClient(connector)
+	return client.Get(gwID, localeServiceID, serviceID, id)
+}
+
+func patchNsxtPolicyIPSecVpnLocalEndpoint(connector *client.RestConnector, isGlobalManager bool, isT0 bool, gwID string, localeServiceID string, serviceID string, id string, obj model.IPSecVpnLocalEndpoint) error {
+	if isT0 {
+		client := getIPSecVpnLocalEndpointsT0Client(connector, isGlobalManager)
+		return client.Patch(gwID, localeServiceID, serviceID, id, obj)
+	}
+	client := t1_ipsec_vpn_services.NewDefaultLocalEndpointsClient(connector)
+	return client.Patch(gwID, localeServiceID, serviceID, id, obj)
+}
+
+func deleteNsxtPolicyIPSecVpnLocalEndpoint(connector *client.RestConnector, isGlobalManager bool, isT0 bool, gwID string, localeServiceID string, serviceID string, id string) error {
+	if isT0 {
+		client := getIPSecVpnLocalEndpointsT0Client(connector, isGlobalManager)
+		return client.Delete(gwID, localeServiceID, serviceID, id)
+	}
+	client := t1_ipsec_vpn_services.NewDefaultLocalEndpointsClient(connector)
+	return client.Delete(gwID, localeServiceID, serviceID, id)
+}
+
+func resourceNsxtPolicyIPSecVpnLocalEndpointExists(connector *client.RestConnector, isGlobalManager bool, servicePath string, id string) (bool, error) {
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	_, err := getNsxtPolicyIPSecVpnLocalEndpoint(connector, isGlobalManager, isT0, gwID, localeServiceID, serviceID, id)
+	if err == nil {
+		return true, nil
+	}
+
+	if isNotFoundError(err) {
+		return false, nil
+	}
+
+	return false, logAPIError("Error retrieving resource", err)
+}
+
+func policyIPSecVpnLocalEndpointFromSchema(d *schema.ResourceData) model.IPSecVpnLocalEndpoint {
+	displayName := d.Get("display_name").(string)
+	description := d.Get("description").(string)
+	tags := getPolicyTagsFromSchema(d)
+	localAddress := d.Get("local_address").(string)
+
+	obj := model.IPSecVpnLocalEndpoint{
+		DisplayName:   &displayName,
+		Description:   &description,
+		Tags:          tags,
+		LocalAddress:  &localAddress,
+		TrustCaPaths:  getStringListFromSchemaList(d, "trust_ca_paths"),
+		TrustCrlPaths: getStringListFromSchemaList(d, "trust_crl_paths"),
+	}
+
+	localID := d.Get("local_id").(string)
+	if localID != "" {
+		obj.LocalId = &localID
+	}
+
+	certificatePath := d.Get("certificate_path").(string)
+	if certificatePath != "" {
+		obj.CertificatePath = &certificatePath
+	}
+
+	return obj
+}
+
+func resourceNsxtPolicyIPSecVpnLocalEndpointCreate(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+	isGlobalManager := isPolicyGlobalManager(m)
+
+	servicePath := d.Get("service_path").(string)
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	if gwID == "" {
+		return fmt.Errorf("IPSec VPN Service path expected, got %s", servicePath)
+	}
+
+	id := d.Get("nsx_id").(string)
+	if id == "" {
+		id = newUUID()
+	} else {
+		exists, err := resourceNsxtPolicyIPSecVpnLocalEndpointExists(connector, isGlobalManager, servicePath, id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("IPSec VPN Local Endpoint with ID '%s' already exists on service %s", id, servicePath)
+		}
+	}
+
+	obj := policyIPSecVpnLocalEndpointFromSchema(d)
+
+	log.Printf("[INFO] Creating IPSec VPN Local Endpoint with ID %s", id)
+	err := patchNsxtPolicyIPSecVpnLocalEndpoint(connector, isGlobalManager, isT0, gwID, localeServiceID, serviceID, id, obj)
+	if err != nil {
+		return handleCreateError("IPSec VPN Local Endpoint", id, err)
+	}
+
+	d.SetId(id)
+	d.Set("nsx_id", id)
+
+	return resourceNsxtPolicyIPSecVpnLocalEndpointRead(d, m)
+}
+
+func resourceNsxtPolicyIPSecVpnLocalEndpointRead(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	if id == "" {
+		return fmt.Errorf("Error obtaining IPSec VPN Local Endpoint ID")
+	}
+
+	servicePath := d.Get("service_path").(string)
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	if gwID == "" {
+		return fmt.Errorf("IPSec VPN Service path expected, got %s", servicePath)
+	}
+
+	obj, err := getNsxtPolicyIPSecVpnLocalEndpoint(connector, isPolicyGlobalManager(m), isT0, gwID, localeServiceID, serviceID, id)
+	if err != nil {
+		return handleReadError(d, "IPSec VPN Local Endpoint", id, err)
+	}
+
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	setPolicyTagsInSchema(d, obj.Tags)
+	d.Set("nsx_id", id)
+	d.Set("path", obj.Path)
+	d.Set("revision", obj.Revision)
+	d.Set("local_address", obj.LocalAddress)
+	d.Set("local_id", obj.LocalId)
+	d.Set("certificate_path", obj.CertificatePath)
+	d.Set("trust_ca_paths", obj.TrustCaPaths)
+	d.Set("trust_crl_paths", obj.TrustCrlPaths)
+
+	return nil
+}
+
+func resourceNsxtPolicyIPSecVpnLocalEndpointUpdate(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	if id == "" {
+		return fmt.Errorf("Error obtaining IPSec VPN Local Endpoint ID")
+	}
+
+	servicePath := d.Get("service_path").(string)
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	if gwID == "" {
+		return fmt.Errorf("IPSec VPN Service path expected, got %s", servicePath)
+	}
+
+	obj := policyIPSecVpnLocalEndpointFromSchema(d)
+	revision := int64(d.Get("revision").(int))
+	obj.Revision = &revision
+
+	err := patchNsxtPolicyIPSecVpnLocalEndpoint(connector, isPolicyGlobalManager(m), isT0, gwID, localeServiceID, serviceID, id, obj)
+	if err != nil {
+		return handleUpdateError("IPSec VPN Local Endpoint", id, err)
+	}
+
+	return resourceNsxtPolicyIPSecVpnLocalEndpointRead(d, m)
+}
+
+func resourceNsxtPolicyIPSecVpnLocalEndpointDelete(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	if id == "" {
+		return fmt.Errorf("Error obtaining IPSec VPN Local Endpoint ID")
+	}
+
+	servicePath := d.Get("service_path").(string)
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	if gwID == "" {
+		return fmt.Errorf("IPSec VPN Service path expected, got %s", servicePath)
+	}
+
+	err := deleteNsxtPolicyIPSecVpnLocalEndpoint(connector, isPolicyGlobalManager(m), isT0, gwID, localeServiceID, serviceID, id)
+	if err != nil {
+		return handleDeleteError("IPSec VPN Local Endpoint", id, err)
+	}
+
+	return nil
+}
+
+func resourceNsxtPolicyIPSecVpnLocalEndpointImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importPath := d.Id()
+	servicePath, id := parseVpnServiceChildPolicyPath(importPath, "ipsec-vpn-services", "local-endpoints")
+	if servicePath == "" {
+		return nil, fmt.Errorf("Please provide IPSec VPN Local Endpoint policy path as an input, for example /infra/tier-0s/<gateway-id>/locale-services/<locale-service-id>/ipsec-vpn-services/<service-id>/local-endpoints/<endpoint-id>")
+	}
+
+	d.Set("service_path", servicePath)
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,195 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var accTestPolicyIPSecVpnLocalEndpointHelperName = getAccTestResourceName()
+
+var accTestPolicyIPSecVpnLocalEndpointCreateAttributes = map[string]string{
+	"display_name":  getAccTestResourceName(),
+	"description":   "terraform created",
+	"local_address": "20.20.0.10",
+	"local_id":      "test-create",
+}
+
+var accTestPolicyIPSecVpnLocalEndpointUpdateAttributes = map[string]string{
+	"display_name":  getAccTestResourceName(),
+	"description":   "terraform updated",
+	"local_address": "20.20.0.20",
+	"local_id":      "test-update",
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnLocalEndpoint_basic(t *testing.T) {
+	testResourceName := "nsxt_policy_ipsec_vpn_local_endpoint.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIPSecVpnLocalEndpointCheckDestroy(state, accTestPolicyIPSecVpnLocalEndpointUpdateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIPSecVpnLocalEndpointTemplate(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnLocalEndpointExists(accTestPolicyIPSecVpnLocalEndpointCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyIPSecVpnLocalEndpointCreateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyIPSecVpnLocalEndpointCreateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "local_address", accTestPolicyIPSecVpnLocalEndpointCreateAttributes["local_address"]),
+					resource.TestCheckResourceAttr(testResourceName, "local_id", accTestPolicyIPSecVpnLocalEndpointCreateAttributes["local_id"]),
+					resource.TestCheckResourceAttrSet(testResourceName, "service_path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnLocalEndpointTemplate(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnLocalEndpointExists(accTestPolicyIPSecVpnLocalEndpointUpdateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyIPSecVpnLocalEndpointUpdateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyIPSecVpnLocalEndpointUpdateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "local_address", accTestPolicyIPSecVpnLocalEndpointUpdateAttributes["local_address"]),
+					resource.TestCheckResourceAttr(testResourceName, "local_id", accTestPolicyIPSecVpnLocalEndpointUpdateAttributes["local_id"]),
+					resource.TestCheckResourceAttrSet(testResourceName, "service_path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnLocalEndpointMinimalistic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnLocalEndpointExists(accTestPolicyIPSecVpnLocalEndpointCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "description", ""),
+					resource.TestCheckResourceAttrSet(testResourceName, "local_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnLocalEndpoint_importBasic(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_policy_ipsec_vpn_local_endpoint.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIPSecVpnLocalEndpointCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIPSecVpnLocalEndpointMinimalistic(),
+			},
+			{
+				ResourceName:      testResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccNsxtPolicyVpnPathImporterGetID(testResourceName),
+			},
+		},
+	})
+}
+
+func testAccNsxtPolicyIPSecVpnLocalEndpointExists(displayName string, resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+
+		connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Policy IPSec VPN Local Endpoint resource %s not found in resources", resourceName)
+		}
+
+		resourceID := rs.Primary.ID
+		if resourceID == "" {
+			return fmt.Errorf("Policy IPSec VPN Local Endpoint resource ID not set in resources")
+		}
+
+		exists, err := resourceNsxtPolicyIPSecVpnLocalEndpointExists(connector, testAccIsGlobalManager(), rs.Primary.Attributes["service_path"], resourceID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("Policy IPSec VPN Local Endpoint %s does not exist", resourceID)
+		}
+
+		return nil
+	}
+}
+
+func testAccNsxtPolicyIPSecVpnLocalEndpointCheckDestroy(state *terraform.State, displayName string) error {
+	connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+	for _, rs := range state.RootModule().Resources {
+
+		if rs.Type != "nsxt_policy_ipsec_vpn_local_endpoint" {
+			continue
+		}
+
+		resourceID := rs.Primary.Attributes["id"]
+		exists, err := resourceNsxtPolicyIPSecVpnLocalEndpointExists(connector, testAccIsGlobalManager(), rs.Primary.Attributes["service_path"], resourceID)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return fmt.Errorf("Policy IPSec VPN Local Endpoint %s still exists", displayName)
+		}
+	}
+	return nil
+}
+
+func testAccNsxtPolicyIPSecVpnLocalEndpointPrerequisites() string {
+	return testAccNsxtPolicyIPSecVpnServicePrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_service" "test" {
+  display_name = "%s"
+  gateway_path = nsxt_policy_tier0_gateway.test.path
+}`, accTestPolicyIPSecVpnLocalEndpointHelperName)
+}
+
+func testAccNsxtPolicyIPSecVpnLocalEndpointTemplate(createFlow bool) string {
+	var attrMap map[string]string
+	if createFlow {
+		attrMap = accTestPolicyIPSecVpnLocalEndpointCreateAttributes
+	} else {
+		attrMap = accTestPolicyIPSecVpnLocalEndpointUpdateAttributes
+	}
+	return testAccNsxtPolicyIPSecVpnLocalEndpointPrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_local_endpoint" "test" {
+  display_name  = "%s"
+  description   = "%s"
+  service_path  = nsxt_policy_ipsec_vpn_service.test.path
+  local_address = "%s"
+  local_id      = "%s"
+
+  tag {
+    scope = "scope1"
+    tag   = "tag1"
+  }
+}`, attrMap["display_name"], attrMap["description"], attrMap["local_address"], attrMap["local_id"])
+}
+
+func testAccNsxtPolicyIPSecVpnLocalEndpointMinimalistic() string {
+	return testAccNsxtPolicyIPSecVpnLocalEndpointPrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_local_endpoint" "test" {
+  display_name  = "%s"
+  service_path  = nsxt_policy_ipsec_vpn_service.test.path
+  local_address = "%s"
+}`, accTestPolicyIPSecVpnLocalEndpointCreateAttributes["display_name"], accTestPolicyIPSecVpnLocalEndpointCreateAttributes["local_address"])
+}
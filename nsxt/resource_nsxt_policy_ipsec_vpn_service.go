@@ -0,0 +1,352 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	gm_tier0s "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/global_infra/tier_0s"
+	gm_t0_locale_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/global_infra/tier_0s/locale_services"
+	t0_locale_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services"
+	t1_locale_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/locale_services"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+var IPSecVpnServiceIkeLogLevelValues = []string{
+	model.IPSecVpnService_IKE_LOG_LEVEL_DEBUG,
+	model.IPSecVpnService_IKE_LOG_LEVEL_INFO,
+	model.IPSecVpnService_IKE_LOG_LEVEL_WARN,
+	model.IPSecVpnService_IKE_LOG_LEVEL_ERROR,
+	model.IPSecVpnService_IKE_LOG_LEVEL_EMERGENCY,
+}
+
+func resourceNsxtPolicyIPSecVpnService() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNsxtPolicyIPSecVpnServiceCreate,
+		Read:   resourceNsxtPolicyIPSecVpnServiceRead,
+		Update: resourceNsxtPolicyIPSecVpnServiceUpdate,
+		Delete: resourceNsxtPolicyIPSecVpnServiceDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceNsxtPolicyIPSecVpnServiceImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"nsx_id":            getNsxIDSchema(),
+			"path":              getPathSchema(),
+			"display_name":      getDisplayNameSchema(),
+			"description":       getDescriptionSchema(),
+			"revision":          getRevisionSchema(),
+			"tag":               getTagsSchema(),
+			"gateway_path":      getPolicyPathSchema(true, true, "Policy path for Tier0 or Tier1 gateway"),
+			"locale_service_id": getComputedLocaleServiceIDSchema(),
+			"site_path": {
+				Type:         schema.TypeString,
+				Description:  "Path of the site the Tier0 gateway locale service belongs to",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validatePolicyPath(),
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable/Disable IPSec VPN service",
+				Optional:    true,
+				Default:     true,
+			},
+			"ha_sync": {
+				Type:        schema.TypeBool,
+				Description: "Enable/Disable IPSec VPN service HA state sync",
+				Optional:    true,
+				Default:     true,
+			},
+			"ike_log_level": {
+				Type:         schema.TypeString,
+				Description:  "Log level for internet key exchange (IKE)",
+				Optional:     true,
+				Default:      model.IPSecVpnService_IKE_LOG_LEVEL_INFO,
+				ValidateFunc: validation.StringInSlice(IPSecVpnServiceIkeLogLevelValues, false),
+			},
+			"bypass_rule": getIPSecVpnBypassRulesSchema(),
+		},
+	}
+}
+
+func getIPSecVpnBypassRulesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Bypass rules for this IPSec VPN service. Bypass rules are prioritized over protect rules of all policy based sessions on the service",
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: getIPSecVpnRuleElemSchema(),
+		},
+	}
+}
+
+func getIPSecVpnBypassRulesFromSchema(d *schema.ResourceData) []model.IPSecVpnRule {
+	ruleList := getIPSecVpnRulesFromList(d.Get("bypass_rule").([]interface{}))
+	for i := range ruleList {
+		action := model.IPSecVpnRule_ACTION_BYPASS
+		ruleList[i].Action = &action
+	}
+	return ruleList
+}
+
+func setIPSecVpnBypassRulesInSchema(d *schema.ResourceData, rules []model.IPSecVpnRule) error {
+	sortedRules := sortIPSecVpnRulesBySequenceNumber(rules)
+	return d.Set("bypass_rule", getIPSecVpnRulesList(sortedRules))
+}
+
+func getIPSecVpnServicesT0Client(connector *client.RestConnector, isGlobalManager bool) t0_locale_services.IpsecVpnServicesClient {
+	if isGlobalManager {
+		return gm_t0_locale_services.NewDefaultIpsecVpnServicesClient(connector)
+	}
+	return t0_locale_services.NewDefaultIpsecVpnServicesClient(connector)
+}
+
+func getNsxtPolicyIPSecVpnService(connector *client.RestConnector, isGlobalManager bool, isT0 bool, gwID string, localeServiceID string, id string) (model.IPSecVpnService, error) {
+	if isT0 {
+		client := getIPSecVpnServicesT0Client(connector, isGlobalManager)
+		return client.Get(gwID, localeServiceID, id)
+	}
+	client := t1_locale_services.NewDefaultIpsecVpnServicesClient(connector)
+	return client.Get(gwID, localeServiceID, id)
+}
+
+func patchNsxtPolicyIPSecVpnService(connector *client.RestConnector, isGlobalManager bool, isT0 bool, gwID string, localeServiceID string, id string, obj model.IPSecVpnService) error {
+	if isT0 {
+		client := getIPSecVpnServicesT0Client(connector, isGlobalManager)
+		return client.Patch(gwID, localeServiceID, id, obj)
+	}
+	client := t1_locale_services.NewDefaultIpsecVpnServicesClient(connector)
+	return client.Patch(gwID, localeServiceID, id, obj)
+}
+
+func deleteNsxtPolicyIPSecVpnService(connector *client.RestConnector, isGlobalManager bool, isT0 bool, gwID string, localeServiceID string, id string) error {
+	if isT0 {
+		client := getIPSecVpnServicesT0Client(connector, isGlobalManager)
+		return client.Delete(gwID, localeServiceID, id)
+	}
+	client := t1_locale_services.NewDefaultIpsecVpnServicesClient(connector)
+	return client.Delete(gwID, localeServiceID, id)
+}
+
+func resourceNsxtPolicyIPSecVpnServiceExists(connector *client.RestConnector, isGlobalManager bool, isT0 bool, gwID string, localeServiceID string, id string) (bool, error) {
+	_, err := getNsxtPolicyIPSecVpnService(connector, isGlobalManager, isT0, gwID, localeServiceID, id)
+	if err == nil {
+		return true, nil
+	}
+
+	if isNotFoundError(err) {
+		return false, nil
+	}
+
+	return false, logAPIError("Error retrieving resource", err)
+}
+
+func getPolicyGatewayLocaleServiceID(connector *client.RestConnector, isT0 bool, gwID string) (string, error) {
+	var localeService *model.LocaleServices
+	var err error
+	if isT0 {
+		localeService, err = getPolicyTier0GatewayLocaleServiceWithEdgeCluster(gwID, connector)
+	} else {
+		localeService, err = getPolicyTier1GatewayLocaleServiceEntry(gwID, connector)
+	}
+	if err != nil {
+		return "", err
+	}
+	if localeService == nil {
+		return "", fmt.Errorf("Edge cluster is mandatory on gateway %s in order to create VPN services", gwID)
+	}
+
+	return *localeService.Id, nil
+}
+
+func policyIPSecVpnServiceFromSchema(d *schema.ResourceData) model.IPSecVpnService {
+	displayName := d.Get("display_name").(string)
+	description := d.Get("description").(string)
+	tags := getPolicyTagsFromSchema(d)
+	enabled := d.Get("enabled").(bool)
+	haSync := d.Get("ha_sync").(bool)
+	ikeLogLevel := d.Get("ike_log_level").(string)
+
+	return model.IPSecVpnService{
+		DisplayName: &displayName,
+		Description: &description,
+		Tags:        tags,
+		Enabled:     &enabled,
+		HaSync:      &haSync,
+		IkeLogLevel: &ikeLogLevel,
+		BypassRules: getIPSecVpnBypassRulesFromSchema(d),
+	}
+}
+
+func resourceNsxtPolicyIPSecVpnServiceCreate(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+	isGlobalManager := isPolicyGlobalManager(m)
+
+	gwPath := d.Get("gateway_path").(string)
+	isT0, gwID := parseGatewayPolicyPath(gwPath)
+	if gwID == "" {
+		return fmt.Errorf("gateway_path is not valid")
+	}
+
+	sitePath := d.Get("site_path").(string)
+	var localeServiceID string
+	var err error
+	if isGlobalManager {
+		if !isT0 {
+			return fmt.Errorf("Tier0 Gateway path expected with NSX Global Manager, got %s", gwPath)
+		}
+		if sitePath == "" {
+			return attributeRequiredGlobalManagerError("site_path", "nsxt_policy_ipsec_vpn_service")
+		}
+		localeServiceID, err = findTier0LocaleServiceForSite(connector, gwID, sitePath)
+	} else {
+		if sitePath != "" {
+			return globalManagerOnlyError()
+		}
+		localeServiceID, err = getPolicyGatewayLocaleServiceID(connector, isT0, gwID)
+	}
+	if err != nil {
+		return err
+	}
+
+	id := d.Get("nsx_id").(string)
+	if id == "" {
+		id = newUUID()
+	} else {
+		exists, err := resourceNsxtPolicyIPSecVpnServiceExists(connector, isGlobalManager, isT0, gwID, localeServiceID, id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("IPSec VPN Service with ID '%s' already exists on Gateway %s", id, gwID)
+		}
+	}
+
+	obj := policyIPSecVpnServiceFromSchema(d)
+
+	log.Printf("[INFO] Creating IPSec VPN Service with ID %s", id)
+	err = patchNsxtPolicyIPSecVpnService(connector, isGlobalManager, isT0, gwID, localeServiceID, id, obj)
+	if err != nil {
+		return handleCreateError("IPSec VPN Service", id, err)
+	}
+
+	d.SetId(id)
+	d.Set("nsx_id", id)
+	d.Set("locale_service_id", localeServiceID)
+
+	return resourceNsxtPolicyIPSecVpnServiceRead(d, m)
+}
+
+func resourceNsxtPolicyIPSecVpnServiceRead(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	if id == "" {
+		return fmt.Errorf("Error obtaining IPSec VPN Service ID")
+	}
+
+	gwPath := d.Get("gateway_path").(string)
+	isT0, gwID := parseGatewayPolicyPath(gwPath)
+	localeServiceID := d.Get("locale_service_id").(string)
+	if gwID == "" || localeServiceID == "" {
+		return fmt.Errorf("Error obtaining Gateway id or Locale Service id")
+	}
+
+	obj, err := getNsxtPolicyIPSecVpnService(connector, isPolicyGlobalManager(m), isT0, gwID, localeServiceID, id)
+	if err != nil {
+		return handleReadError(d, "IPSec VPN Service", id, err)
+	}
+
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	setPolicyTagsInSchema(d, obj.Tags)
+	d.Set("nsx_id", id)
+	d.Set("path", obj.Path)
+	d.Set("revision", obj.Revision)
+	d.Set("enabled", obj.Enabled)
+	d.Set("ha_sync", obj.HaSync)
+	d.Set("ike_log_level", obj.IkeLogLevel)
+
+	return setIPSecVpnBypassRulesInSchema(d, obj.BypassRules)
+}
+
+func resourceNsxtPolicyIPSecVpnServiceUpdate(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	gwPath := d.Get("gateway_path").(string)
+	isT0, gwID := parseGatewayPolicyPath(gwPath)
+	localeServiceID := d.Get("locale_service_id").(string)
+	if id == "" || gwID == "" || localeServiceID == "" {
+		return fmt.Errorf("Error obtaining Gateway id or Locale Service id")
+	}
+
+	obj := policyIPSecVpnServiceFromSchema(d)
+	revision := int64(d.Get("revision").(int))
+	obj.Revision = &revision
+
+	err := patchNsxtPolicyIPSecVpnService(connector, isPolicyGlobalManager(m), isT0, gwID, localeServiceID, id, obj)
+	if err != nil {
+		return handleUpdateError("IPSec VPN Service", id, err)
+	}
+
+	return resourceNsxtPolicyIPSecVpnServiceRead(d, m)
+}
+
+func resourceNsxtPolicyIPSecVpnServiceDelete(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	gwPath := d.Get("gateway_path").(string)
+	isT0, gwID := parseGatewayPolicyPath(gwPath)
+	localeServiceID := d.Get("locale_service_id").(string)
+	if id == "" || gwID == "" || localeServiceID == "" {
+		return fmt.Errorf("Error obtaining Gateway id or Locale Service id")
+	}
+
+	err := deleteNsxtPolicyIPSecVpnService(connector, isPolicyGlobalManager(m), isT0, gwID, localeServiceID, id)
+	if err != nil {
+		return handleDeleteError("IPSec VPN Service", id, err)
+	}
+
+	return nil
+}
+
+func resourceNsxtPolicyIPSecVpnServiceImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importPath := d.Id()
+	isT0, gwID, localeServiceID, id := parseIPSecVpnServicePolicyPath(importPath)
+	if gwID == "" {
+		return nil, fmt.Errorf("Please provide IPSec VPN Service policy path as an input, for example /infra/tier-0s/<gateway-id>/locale-services/<locale-service-id>/ipsec-vpn-services/<service-id>")
+	}
+
+	gwType := "tier-1s"
+	if isT0 {
+		gwType = "tier-0s"
+	}
+	infraPrefix := "/infra"
+	if isPolicyGlobalManager(m) {
+		infraPrefix = "/global-infra"
+		if isT0 {
+			connector := getPolicyConnector(m)
+			client := gm_tier0s.NewDefaultLocaleServicesClient(connector)
+			localeService, err := client.Get(gwID, localeServiceID)
+			if err != nil {
+				return nil, err
+			}
+			if localeService.EdgeClusterPath != nil {
+				d.Set("site_path", getSitePathFromEdgePath(*localeService.EdgeClusterPath))
+			}
+		}
+	}
+	d.Set("gateway_path", fmt.Sprintf("%s/%s/%s", infraPrefix, gwType, gwID))
+	d.Set("locale_service_id", localeServiceID)
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -4,20 +4,19 @@
 package nsxt
 
 import (
+	"context"
 	"fmt"
 	"log"
-
-	//"strconv"
-	//"strings"
+	"net"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
-
-	//"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
-	//"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services/bgp"
-	ipsec_vpn_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services/ipsec_vpn_services"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	gm_t0_ipsec_vpn_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/global_infra/tier_0s/locale_services/ipsec_vpn_services"
+	t0_ipsec_vpn_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services/ipsec_vpn_services"
+	t1_ipsec_vpn_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/locale_services/ipsec_vpn_services"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 )
 
@@ -46,20 +45,30 @@ var IPSecVpnSessionComplianceSuite = []string{
 	model.IPSecVpnSession_COMPLIANCE_SUITE_NONE,
 }
 
+// Compliance suites that only allow certificate based authentication
+var IPSecVpnSessionCertificateOnlyComplianceSuites = []string{
+	model.IPSecVpnSession_COMPLIANCE_SUITE_CNSA,
+	model.IPSecVpnSession_COMPLIANCE_SUITE_SUITE_B_GCM_128,
+	model.IPSecVpnSession_COMPLIANCE_SUITE_SUITE_B_GCM_256,
+	model.IPSecVpnSession_COMPLIANCE_SUITE_PRIME,
+}
+
+// Bypass rules apply to all sessions of the service and are configured on the
+// IPSec VPN service resource, session rules can only protect traffic
 var IPSecRulesActionValues = []string{
 	model.IPSecVpnRule_ACTION_PROTECT,
-	model.IPSecVpnRule_ACTION_BYPASS,
 }
 
 func resourceNsxtPolicyIPSecVpnSession() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Create: resourceNsxtPolicyIPSecVpnSessionCreate,
 		Read:   resourceNsxtPolicyIPSecVpnSessionRead,
 		Update: resourceNsxtPolicyIPSecVpnSessionUpdate,
 		Delete: resourceNsxtPolicyIPSecVpnSessionDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceNsxtPolicyIPSecVpnSessionImport,
 		},
+		CustomizeDiff: resourceNsxtPolicyIPSecVpnSessionValidate,
 
 		Schema: map[string]*schema.Schema{
 			"nsx_id":       getNsxIDSchema(),
@@ -73,16 +82,18 @@ func resourceNsxtPolicyIPSecVpnSession() *schema.Resource {
 				Description:  "A Policy Based VPN requires to define protect rules that match local and peer subnets. IPSec security associations is negotiated for each pair of local and peer subnet. A Route Based VPN is more flexible, more powerful and recommended over policy based VPN. IP Tunnel port is created and all traffic routed via tunnel port is protected. Routes can be configured statically or can be learned through BGP. A route based VPN is must for establishing redundant VPN session to remote site.",
 				ValidateFunc: validation.StringInSlice(IPSecVpnSessionResourceType, false),
 				Optional:     true,
+				ForceNew:     true,
 			},
 			"compliance_suite": {
 				Type:         schema.TypeString,
-				Description:  "Connection initiation mode used by local endpoint to establish ike connection with peer site. INITIATOR - In this mode local endpoint initiates tunnel setup and will also respond to incoming tunnel setup requests from peer gateway. RESPOND_ONLY - In this mode, local endpoint shall only respond to incoming tunnel setup requests. It shall not initiate the tunnel setup. ON_DEMAND - In this mode local endpoint will initiate tunnel creation once first packet matching the policy rule is received and will also respond to incoming initiation request.",
+				Description:  "Compliance suite. When set to a value other than NONE, IKE and tunnel profiles are assigned by the system according to the suite and can not be specified.",
 				ValidateFunc: validation.StringInSlice(IPSecVpnSessionComplianceSuite, false),
 				Optional:     true,
+				Default:      model.IPSecVpnSession_COMPLIANCE_SUITE_NONE,
 			},
 			"connection_initiation_mode": {
 				Type:         schema.TypeString,
-				Description:  "Compliance suite.",
+				Description:  "Connection initiation mode used by local endpoint to establish ike connection with peer site. INITIATOR - In this mode local endpoint initiates tunnel setup and will also respond to incoming tunnel setup requests from peer gateway. RESPOND_ONLY - In this mode, local endpoint shall only respond to incoming tunnel setup requests. It shall not initiate the tunnel setup. ON_DEMAND - In this mode local endpoint will initiate tunnel creation once first packet matching the policy rule is received and will also respond to incoming initiation request.",
 				ValidateFunc: validation.StringInSlice(IPSecVpnSessionConnectionInitiationMode, false),
 				Optional:     true,
 				Default:      "INITIATOR",
@@ -107,8 +118,15 @@ func resourceNsxtPolicyIPSecVpnSession() *schema.Resource {
 				Default:     "/infra/ipsec-vpn-dpd-profiles/nsx-default-l3vpn-dpd-profile",
 			},
 			"psk": {
-				Type:        schema.TypeString,
-				Description: "IPSec Pre-shared key. Maximum length of this field is 128 characters.",
+				Type:         schema.TypeString,
+				Description:  "IPSec Pre-shared key. Maximum length of this field is 128 characters.",
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(1, 128),
+			},
+			"psk_version": {
+				Type:        schema.TypeInt,
+				Description: "Arbitrary number that triggers pre-shared key update when changed",
 				Optional:    true,
 			},
 			"peer_id": {
@@ -124,7 +142,8 @@ func resourceNsxtPolicyIPSecVpnSession() *schema.Resource {
 			"tunnel_profile_path": {
 				Type:        schema.TypeString,
 				Description: "Policy path referencing Tunnel profile to be used. Default is set to system default profile.",
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"local_endpoint_path": {
 				Type:        schema.TypeString,
@@ -133,87 +152,349 @@ func resourceNsxtPolicyIPSecVpnSession() *schema.Resource {
 			},
 			"ike_profile_path": {
 				Type:        schema.TypeString,
-				Description: "Policy path referencing IKE profile.",
+				Description: "Policy path referencing IKE profile. Default is set to system default profile.",
 				Optional:    true,
+				Computed:    true,
 			},
-			"tier0_id": {
-				Type:        schema.TypeString,
-				Description: "Unique identifier of the T0 resource.",
-				Optional:    true,
-				Default:     "vmc",
-			},
-			"locale_service": {
-				Type:        schema.TypeString,
-				Description: "Unique identifier of the Locale Service resource.",
-				Optional:    true,
-				Default:     "default",
-			},
-			"service_id": {
-				Type:        schema.TypeString,
-				Description: "Unique identifier of the Service.",
-				Optional:    true,
-				Default:     "default",
+			"service_path": {
+				Type:         schema.TypeString,
+				Description:  "Policy path for IPSec VPN service on Tier0 or Tier1 gateway",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateVpnServicePolicyPath("ipsec-vpn-services"),
 			},
 			"subnets": {
 				Type:        schema.TypeList,
 				Description: "IP Tunnel interface (commonly referred as VTI) subnet.",
 				Optional:    true,
+				Deprecated:  "Use tunnel_interface instead",
 				Elem: &schema.Schema{
 					Type:         schema.TypeString,
 					ValidateFunc: validateSingleIP(),
 				},
+				ConflictsWith: []string{"tunnel_interface"},
 			},
-			/*
-				"sources": {
-					Type:        schema.TypeList,
-					Description: "List of local subnets. Specifying no value is interpreted as 0.0.0.0/0.",
+			"rule": getIPSecVPNRulesSchema(),
+			"prefix_length": {
+				Type:          schema.TypeInt,
+				Description:   "Subnet Prefix Length.",
+				Optional:      true,
+				Deprecated:    "Use tunnel_interface instead",
+				ValidateFunc:  validation.IntBetween(1, 128),
+				ConflictsWith: []string{"tunnel_interface"},
+			},
+			"tunnel_interface": getIPSecVpnTunnelInterfaceSchema(),
+		},
+	}
+
+	// service_path replaced tier0_id, locale_service and service_id attributes in version 1
+	resource.SchemaVersion = 1
+	resource.StateUpgraders = getVpnSessionStateUpgraders(resource, "ipsec-vpn-services")
+
+	return resource
+}
+
+func getIPSecVpnTunnelInterfaceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "IP Tunnel interfaces (commonly referred as VTI), relevant for Route Based sessions only",
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"nsx_id": {
+					Type:        schema.TypeString,
+					Description: "NSX ID of the tunnel interface",
+					Optional:    true,
+					Computed:    true,
+				},
+				"display_name": {
+					Type:        schema.TypeString,
+					Description: "Display name of the tunnel interface",
 					Optional:    true,
+					Computed:    true,
+				},
+				"path": {
+					Type:        schema.TypeString,
+					Description: "Policy path of the tunnel interface",
+					Computed:    true,
+				},
+				"peer_ip_addresses": {
+					Type:        schema.TypeList,
+					Description: "IP addresses of the peer end of the tunnel interface, derived for /30 and /31 subnets (/126 and /127 for IPv6)",
+					Computed:    true,
 					Elem: &schema.Schema{
-						Type:         schema.TypeString,
-						ValidateFunc: validateCidrOrIPOrRange(),
+						Type: schema.TypeString,
 					},
 				},
-				"destinations": {
+				"ip_subnet": {
 					Type:        schema.TypeList,
-					Description: "List of remote subnets used in policy-based L3Vpn.",
-					Optional:    true,
-					Elem: &schema.Schema{
-						Type:         schema.TypeString,
-						ValidateFunc: validateCidrOrIPOrRange(),
+					Description: "IP subnets of the tunnel interface",
+					Required:    true,
+					MinItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"ip_addresses": {
+								Type:        schema.TypeList,
+								Description: "IP addresses assigned to the interface",
+								Required:    true,
+								MinItems:    1,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validateSingleIP(),
+								},
+							},
+							"prefix_length": {
+								Type:         schema.TypeInt,
+								Description:  "Subnet prefix length, 1-32 for IPv4 and 1-128 for IPv6 addresses",
+								Required:     true,
+								ValidateFunc: validation.IntBetween(1, 128),
+							},
+						},
 					},
 				},
-			*/
-			"rule": getIPSecVPNRulesSchema(),
-			"prefix_length": {
-				Type:         schema.TypeInt,
-				Description:  "Subnet Prefix Length.",
-				Optional:     true,
-				ValidateFunc: validation.IntBetween(1, 255),
 			},
 		},
 	}
 }
 
+func getIPSecVpnTunnelInterfacesFromSchema(d *schema.ResourceData) []model.IPSecVpnTunnelInterface {
+	var vtiList []model.IPSecVpnTunnelInterface
+	for _, vti := range d.Get("tunnel_interface").([]interface{}) {
+		data := vti.(map[string]interface{})
+		vtiID := data["nsx_id"].(string)
+		if vtiID == "" {
+			vtiID = newUUID()
+		}
+		var ipSubnets []model.TunnelInterfaceIPSubnet
+		for _, subnet := range data["ip_subnet"].([]interface{}) {
+			subnetData := subnet.(map[string]interface{})
+			prefixLength := int64(subnetData["prefix_length"].(int))
+			ipSubnets = append(ipSubnets, model.TunnelInterfaceIPSubnet{
+				IpAddresses:  interfaceListToStringList(subnetData["ip_addresses"].([]interface{})),
+				PrefixLength: &prefixLength,
+			})
+		}
+		elem := model.IPSecVpnTunnelInterface{
+			Id:        &vtiID,
+			IpSubnets: ipSubnets,
+		}
+		displayName := data["display_name"].(string)
+		if displayName != "" {
+			elem.DisplayName = &displayName
+		}
+		vtiList = append(vtiList, elem)
+	}
+	return vtiList
+}
+
+func setIPSecVpnTunnelInterfacesInSchema(d *schema.ResourceData, sessionPath string, vtis []model.IPSecVpnTunnelInterface) error {
+	var vtiList []map[string]interface{}
+	for _, vti := range vtis {
+		elem := make(map[string]interface{})
+		elem["nsx_id"] = vti.Id
+		elem["display_name"] = vti.DisplayName
+		elem["path"] = getIPSecVpnTunnelInterfacePath(sessionPath, vti)
+		var ipSubnets []map[string]interface{}
+		var peerAddresses []string
+		for _, subnet := range vti.IpSubnets {
+			subnetElem := make(map[string]interface{})
+			subnetElem["ip_addresses"] = subnet.IpAddresses
+			subnetElem["prefix_length"] = subnet.PrefixLength
+			ipSubnets = append(ipSubnets, subnetElem)
+			if subnet.PrefixLength == nil {
+				continue
+			}
+			for _, address := range subnet.IpAddresses {
+				peerAddress := getIPSecVpnTunnelInterfacePeerAddress(address, *subnet.PrefixLength)
+				if peerAddress != "" {
+					peerAddresses = append(peerAddresses, peerAddress)
+				}
+			}
+		}
+		elem["ip_subnet"] = ipSubnets
+		elem["peer_ip_addresses"] = peerAddresses
+		vtiList = append(vtiList, elem)
+	}
+	return d.Set("tunnel_interface", vtiList)
+}
+
+func validateIPSecVpnTunnelInterfaceSubnet(addresses []string, prefixLength int) error {
+	for _, address := range addresses {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			// value not known yet
+			continue
+		}
+		if ip.To4() != nil && prefixLength > 32 {
+			return fmt.Errorf("prefix_length %d is not valid for IPv4 address %s, expected 1-32", prefixLength, address)
+		}
+	}
+	return nil
+}
+
+func validateIPSecVpnSessionTunnelInterfaces(d *schema.ResourceDiff) error {
+	vpnType := d.Get("vpn_type").(string)
+	vtis := d.Get("tunnel_interface").([]interface{})
+	subnets := interfaceListToStringList(d.Get("subnets").([]interface{}))
+	if vpnType == model.IPSecVpnSession_RESOURCE_TYPE_POLICYBASEDIPSECVPNSESSION {
+		if len(subnets) > 0 {
+			return fmt.Errorf("subnets are only relevant for %s", model.IPSecVpnSession_RESOURCE_TYPE_ROUTEBASEDIPSECVPNSESSION)
+		}
+		if len(vtis) > 0 {
+			return fmt.Errorf("tunnel_interface is only relevant for %s", model.IPSecVpnSession_RESOURCE_TYPE_ROUTEBASEDIPSECVPNSESSION)
+		}
+		return nil
+	}
+
+	// Empty tunnel interface list is omitted from PATCH request, hence removal
+	// of all tunnel interfaces would never be applied
+	if vpnType == model.IPSecVpnSession_RESOURCE_TYPE_ROUTEBASEDIPSECVPNSESSION && len(vtis) == 0 && len(subnets) == 0 &&
+		d.NewValueKnown("tunnel_interface") && d.NewValueKnown("subnets") {
+		return fmt.Errorf("at least one tunnel_interface is required for %s", model.IPSecVpnSession_RESOURCE_TYPE_ROUTEBASEDIPSECVPNSESSION)
+	}
+
+	if len(subnets) > 0 {
+		return validateIPSecVpnTunnelInterfaceSubnet(subnets, d.Get("prefix_length").(int))
+	}
+
+	for _, vti := range vtis {
+		data := vti.(map[string]interface{})
+		for _, subnet := range data["ip_subnet"].([]interface{}) {
+			subnetData := subnet.(map[string]interface{})
+			addresses := interfaceListToStringList(subnetData["ip_addresses"].([]interface{}))
+			if err := validateIPSecVpnTunnelInterfaceSubnet(addresses, subnetData["prefix_length"].(int)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateIPSecVpnSessionComplianceSuite(d *schema.ResourceDiff) error {
+	complianceSuite := d.Get("compliance_suite").(string)
+	if complianceSuite == "" || complianceSuite == model.IPSecVpnSession_COMPLIANCE_SUITE_NONE {
+		return nil
+	}
+
+	// Profiles are assigned by NSX according to the compliance suite, hence only
+	// values that were not modified by the user (computed from NSX) are allowed
+	for _, attr := range []string{"ike_profile_path", "tunnel_profile_path"} {
+		if d.Get(attr).(string) != "" && (d.Id() == "" || d.HasChange(attr)) {
+			return fmt.Errorf("%s can not be specified with compliance_suite %s, the profile is determined by the compliance suite", attr, complianceSuite)
+		}
+	}
+
+	if d.HasChange("compliance_suite") && d.Id() != "" {
+		// Let NSX assign profiles matching the new compliance suite
+		for _, attr := range []string{"ike_profile_path", "tunnel_profile_path"} {
+			if err := d.SetNewComputed(attr); err != nil {
+				return err
+			}
+		}
+	}
+
+	authenticationMode := d.Get("authentication_mode").(string)
+	if authenticationMode == model.IPSecVpnSession_AUTHENTICATION_MODE_PSK {
+		for _, suite := range IPSecVpnSessionCertificateOnlyComplianceSuites {
+			if suite == complianceSuite {
+				return fmt.Errorf("compliance_suite %s requires authentication_mode %s", complianceSuite, model.IPSecVpnSession_AUTHENTICATION_MODE_CERTIFICATE)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateIPSecVpnSessionAuthentication(d *schema.ResourceDiff) error {
+	authenticationMode := d.Get("authentication_mode").(string)
+	if authenticationMode != model.IPSecVpnSession_AUTHENTICATION_MODE_CERTIFICATE {
+		return nil
+	}
+
+	if d.Get("psk").(string) != "" {
+		return fmt.Errorf("psk can not be specified with authentication_mode %s", authenticationMode)
+	}
+
+	// Values might be unknown at plan time if they reference resources that are not created yet
+	if d.NewValueKnown("peer_id") && d.Get("peer_id").(string) == "" {
+		return fmt.Errorf("peer_id is required with authentication_mode %s, it should be set to the subject distinguished name of the peer certificate", authenticationMode)
+	}
+
+	if d.NewValueKnown("local_endpoint_path") && d.Get("local_endpoint_path").(string) == "" {
+		return fmt.Errorf("local_endpoint_path is required with authentication_mode %s, the local endpoint provides the site certificate and trusted CAs", authenticationMode)
+	}
+
+	return nil
+}
+
+func resourceNsxtPolicyIPSecVpnSessionValidate(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if err := validateIPSecVpnSessionComplianceSuite(d); err != nil {
+		return err
+	}
+
+	if err := validateIPSecVpnSessionTunnelInterfaces(d); err != nil {
+		return err
+	}
+
+	return validateIPSecVpnSessionAuthentication(d)
+}
+
+// Certificate authentication requires the local endpoint to present a site certificate
+// and to trust the CA that issued the peer certificate
+func validateIPSecVpnSessionLocalEndpointCertificate(connector *client.RestConnector, isGlobalManager bool, localEndpointPath string) error {
+	servicePath, endpointID := parseVpnServiceChildPolicyPath(localEndpointPath, "ipsec-vpn-services", "local-endpoints")
+	if servicePath == "" {
+		return fmt.Errorf("IPSec VPN Local Endpoint path expected, got %s", localEndpointPath)
+	}
+
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	endpoint, err := getNsxtPolicyIPSecVpnLocalEndpoint(connector, isGlobalManager, isT0, gwID, localeServiceID, serviceID, endpointID)
+	if err != nil {
+		return logAPIError(fmt.Sprintf("Error retrieving IPSec VPN Local Endpoint %s", localEndpointPath), err)
+	}
+
+	if endpoint.CertificatePath == nil || *endpoint.CertificatePath == "" {
+		return fmt.Errorf("IPSec VPN Local Endpoint %s has no certificate_path, which is required for authentication_mode %s", localEndpointPath, model.IPSecVpnSession_AUTHENTICATION_MODE_CERTIFICATE)
+	}
+
+	if len(endpoint.TrustCaPaths) == 0 {
+		return fmt.Errorf("IPSec VPN Local Endpoint %s has no trust_ca_paths, which are required for authentication_mode %s", localEndpointPath, model.IPSecVpnSession_AUTHENTICATION_MODE_CERTIFICATE)
+	}
+
+	return nil
+}
+
 func getIPSecVPNSessionFromSchema(d *schema.ResourceData) (*data.StructValue, error) {
 	converter := bindings.NewTypeConverter()
 	converter.SetMode(bindings.REST)
 
-	Psk := d.Get("psk").(string)
+	// NSX never returns the pre-shared key, thus it is only sent when it
+	// is set for the first time or rotated
+	var Psk *string
+	psk := d.Get("psk").(string)
+	if psk != "" && (d.Id() == "" || d.HasChange("psk") || d.HasChange("psk_version")) {
+		Psk = &psk
+	}
 	PeerID := d.Get("peer_id").(string)
 	PeerAddress := d.Get("peer_address").(string)
 	displayName := d.Get("display_name").(string)
 	description := d.Get("description").(string)
-	IkeProfilePath := d.Get("ike_profile_path").(string)
+	tags := getPolicyTagsFromSchema(d)
+	// IKE and tunnel profiles are assigned by NSX when not specified
+	var IkeProfilePath *string
+	ikeProfilePath := d.Get("ike_profile_path").(string)
+	if ikeProfilePath != "" {
+		IkeProfilePath = &ikeProfilePath
+	}
+	var TunnelProfilePath *string
+	tunnelProfilePath := d.Get("tunnel_profile_path").(string)
+	if tunnelProfilePath != "" {
+		TunnelProfilePath = &tunnelProfilePath
+	}
 	ResourceType := d.Get("vpn_type").(string)
 	LocalEndpointPath := d.Get("local_endpoint_path").(string)
-	//var LocalEndpointPath string
-	//if LocalEndpointVar == "Private" {
-	//	LocalEndpointPath = "/infra/tier-0s/vmc/locale-services/default/ipsec-vpn-services/default/local-endpoints/Private-IP1"
-	// else {
-	//	LocalEndpointPath = "/infra/tier-0s/vmc/locale-services/default/ipsec-vpn-services/default/local-endpoints/Public-IP1"
-	//}
 	DpdProfilePath := d.Get("dpd_profile_path").(string)
-	TunnelProfilePath := d.Get("tunnel_profile_path").(string)
 	ConnectionInitiationMode := d.Get("connection_initiation_mode").(string)
 	AuthenticationMode := d.Get("authentication_mode").(string)
 	ComplianceSuite := d.Get("compliance_suite").(string)
@@ -221,28 +502,33 @@ func getIPSecVPNSessionFromSchema(d *schema.ResourceData) (*data.StructValue, er
 	Enabled := d.Get("enabled").(bool)
 
 	if ResourceType == "RouteBasedIPSecVpnSession" {
-		TunnelInterface := interfaceListToStringList(d.Get("subnets").([]interface{}))
-		var IPSubnets []model.TunnelInterfaceIPSubnet
-		IPSubnet := model.TunnelInterfaceIPSubnet{
-			IpAddresses:  TunnelInterface,
-			PrefixLength: &PrefixLengh,
-		}
-		IPSubnets = append(IPSubnets, IPSubnet)
 		var VTIlist []model.IPSecVpnTunnelInterface
+		TunnelInterface := interfaceListToStringList(d.Get("subnets").([]interface{}))
+		if len(TunnelInterface) > 0 {
+			var IPSubnets []model.TunnelInterfaceIPSubnet
+			IPSubnet := model.TunnelInterfaceIPSubnet{
+				IpAddresses:  TunnelInterface,
+				PrefixLength: &PrefixLengh,
+			}
+			IPSubnets = append(IPSubnets, IPSubnet)
 
-		vti := model.IPSecVpnTunnelInterface{
-			IpSubnets:   IPSubnets,
-			DisplayName: &displayName,
-		}
+			vti := model.IPSecVpnTunnelInterface{
+				IpSubnets:   IPSubnets,
+				DisplayName: &displayName,
+			}
 
-		VTIlist = append(VTIlist, vti)
+			VTIlist = append(VTIlist, vti)
+		} else {
+			VTIlist = getIPSecVpnTunnelInterfacesFromSchema(d)
+		}
 
 		routeObj := model.RouteBasedIPSecVpnSession{
 			DisplayName:              &displayName,
 			Description:              &description,
-			IkeProfilePath:           &IkeProfilePath,
+			Tags:                     tags,
+			IkeProfilePath:           IkeProfilePath,
 			LocalEndpointPath:        &LocalEndpointPath,
-			TunnelProfilePath:        &TunnelProfilePath,
+			TunnelProfilePath:        TunnelProfilePath,
 			DpdProfilePath:           &DpdProfilePath,
 			ConnectionInitiationMode: &ConnectionInitiationMode,
 			ComplianceSuite:          &ComplianceSuite,
@@ -252,7 +538,7 @@ func getIPSecVPNSessionFromSchema(d *schema.ResourceData) (*data.StructValue, er
 			TunnelInterfaces:         VTIlist,
 			PeerAddress:              &PeerAddress,
 			PeerId:                   &PeerID,
-			Psk:                      &Psk,
+			Psk:                      Psk,
 		}
 
 		dataValue, err := converter.ConvertToVapi(routeObj, model.RouteBasedIPSecVpnSessionBindingType())
@@ -263,52 +549,14 @@ func getIPSecVPNSessionFromSchema(d *schema.ResourceData) (*data.StructValue, er
 	}
 
 	if ResourceType == "PolicyBasedIPSecVpnSession" {
-		log.Println("#################################################1")
 		IPSecVpnRules := getIPSecVPNRulesFromSchema(d)
-		log.Println("#################################################10")
-		/*
-
-			var exampleSubnet string
-			exampleSubnet = "10.10.10.0/24"
-			log.Println("#################################################")
-			log.Println(exampleSubnet)
-			DestinationsObj := model.IPSecVpnSubnet{
-				Subnet: &exampleSubnet,
-			}
-			log.Println(DestinationsObj)
-			var DestinationsObjects []model.IPSecVpnSubnet
-			DestinationsObjects = append(DestinationsObjects, DestinationsObj)
-			var sourceSubnet string
-			sourceSubnet = "10.10.11.0/24"
-			SourcesObj := model.IPSecVpnSubnet{
-				Subnet: &sourceSubnet,
-			}
-			var SourcesObjects []model.IPSecVpnSubnet
-			SourcesObjects = append(SourcesObjects, SourcesObj)
-
-			rule_id := newUUID()
-			var resourceType string
-			resourceType = "IPSecVpnRule"
-			//var action string
-			//action = "PROTECT"
-			IPSecVpnRule := model.IPSecVpnRule{
-				Sources:      SourcesObjects,
-				Destinations: DestinationsObjects,
-				UniqueId:     &rule_id,
-				Id:           &rule_id,
-				ResourceType: &resourceType,
-				//Action:       &action,
-			}
-			log.Println(IPSecVpnRule)
-			var IPSecVpnRules []model.IPSecVpnRule
-			IPSecVpnRules = append(IPSecVpnRules, IPSecVpnRule)
-		*/
 		policyObj := model.PolicyBasedIPSecVpnSession{
 			DisplayName:              &displayName,
 			Description:              &description,
-			IkeProfilePath:           &IkeProfilePath,
+			Tags:                     tags,
+			IkeProfilePath:           IkeProfilePath,
 			LocalEndpointPath:        &LocalEndpointPath,
-			TunnelProfilePath:        &TunnelProfilePath,
+			TunnelProfilePath:        TunnelProfilePath,
 			DpdProfilePath:           &DpdProfilePath,
 			ConnectionInitiationMode: &ConnectionInitiationMode,
 			ComplianceSuite:          &ComplianceSuite,
@@ -318,7 +566,7 @@ func getIPSecVPNSessionFromSchema(d *schema.ResourceData) (*data.StructValue, er
 			Rules:                    IPSecVpnRules,
 			PeerAddress:              &PeerAddress,
 			PeerId:                   &PeerID,
-			Psk:                      &Psk,
+			Psk:                      Psk,
 		}
 		dataValue, err := converter.ConvertToVapi(policyObj, model.PolicyBasedIPSecVpnSessionBindingType())
 		if err != nil {
@@ -333,156 +581,122 @@ func getIPSecVPNSessionFromSchema(d *schema.ResourceData) (*data.StructValue, er
 }
 
 func getIPSecVPNRulesSchema() *schema.Schema {
+	elemSchema := getIPSecVpnRuleElemSchema()
+	elemSchema["action"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "Rule action, only PROTECT is supported. Bypass rules are defined per IPSec VPN service with bypass_rule",
+		Default:      model.IPSecVpnRule_ACTION_PROTECT,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice(IPSecRulesActionValues, false),
+	}
+
 	return &schema.Schema{
 		Type:        schema.TypeList,
 		Description: "For policy-based IPsec VPNs, a security policy specifies as its action the VPN tunnel to be used for transit traffic that meets the policy’s match criteria.",
 		Optional:    true,
 		Elem: &schema.Resource{
-			Schema: map[string]*schema.Schema{
-				"sources": {
-					Type:        schema.TypeSet,
-					Description: "List of local subnets. Specifying no value is interpreted as 0.0.0.0/0.",
-					Elem: &schema.Schema{
-						Type:         schema.TypeString,
-						ValidateFunc: validateCidr(),
-					},
-					Required: true,
-				},
-				"destinations": {
-					Type:        schema.TypeSet,
-					Description: "List of remote subnets used in policy-based L3Vpn.",
-					Elem: &schema.Schema{
-						Type:         schema.TypeString,
-						ValidateFunc: validateCidr(),
-					},
-					Required: true,
-				},
-				"action": {
-					Type:         schema.TypeString,
-					Description:  "PROTECT - Protect rules are defined per policy based IPSec VPN session. BYPASS - Bypass rules are defined per IPSec VPN service and affects all policy based IPSec VPN sessions. Bypass rules are prioritized over protect rules.",
-					Default:      model.IPSecVpnRule_ACTION_PROTECT,
-					Optional:     true,
-					ValidateFunc: validation.StringInSlice(IPSecRulesActionValues, false),
-				},
-			},
+			Schema: elemSchema,
 		},
 	}
 }
 
-//func getSubnetsFromSchema(d *schema.ResourceData) []manager.IpPoolSubnet {
-//subnets := d.Get("subnet").([]interface{})
-//var subnetsList []manager.IpPoolSubnet
-//for _, subnet := range subnets {
-//	data := subnet.(map[string]interface{})
-//	elem := manager.IpPoolSubnet{
-//		Cidr:             data["cidr"].(string),
-//		DnsSuffix:        data["dns_suffix"].(string),
-//		GatewayIp:        data["gateway_ip"].(string),
-//		DnsNameservers:   interface2StringList(data["dns_nameservers"].([]interface{})),
-//		AllocationRanges: getAllocationRangesFromRanges(data["allocation_ranges"].([]interface{})),
-//	}
-//
-//	subnetsList = append(subnetsList, elem)
-//}
-//	return subnetsList
-//}
-
-func getSourcesSubnetsFromSchema(d *schema.ResourceData) []model.IPSecVpnSubnet {
-	IPSecVpnSubnets := d.Get("sources").(*schema.Set).List()
-	IPSecVpnSubnetList := make([]model.IPSecVpnSubnet, 0)
-	for _, subnet := range IPSecVpnSubnets {
-		data := subnet.(map[string]interface{})
-		subnet := data["sources"].(string)
-		elem := model.IPSecVpnSubnet{
-			Subnet: &subnet,
-		}
-		IPSecVpnSubnetList = append(IPSecVpnSubnetList, elem)
+func getIPSecVPNRulesFromSchema(d *schema.ResourceData) []model.IPSecVpnRule {
+	return getIPSecVpnRulesFromList(d.Get("rule").([]interface{}))
+}
+
+func setIPSecVPNRulesInSchema(d *schema.ResourceData, rules []model.IPSecVpnRule) error {
+	sortedRules := sortIPSecVpnRulesBySequenceNumber(rules)
+	rulesList := getIPSecVpnRulesList(sortedRules)
+	for i, rule := range sortedRules {
+		rulesList[i]["action"] = rule.Action
 	}
-	return IPSecVpnSubnetList
+
+	return d.Set("rule", rulesList)
 }
 
-func getIPSecVPNRulesFromSchema(d *schema.ResourceData) []model.IPSecVpnRule {
-	rules := d.Get("rule").([]interface{})
-	log.Println("#################################################2")
-	var ruleList []model.IPSecVpnRule
-	for _, rule := range rules {
-		data := rule.(map[string]interface{})
-		log.Println("#################################################3")
-		action := data["action"].(string)
-		log.Println(action)
-		log.Println("#################################################4")
-		sourceRanges := interface2StringList(data["sources"].(*schema.Set).List())
-		destinationRanges := interface2StringList(data["destinations"].(*schema.Set).List())
-
-		/// Source Subnets
-
-		log.Println("#################################################5")
-		SourceIPSecVpnSubnetList := make([]model.IPSecVpnSubnet, 0)
-		if len(sourceRanges) > 0 {
-			for _, element := range sourceRanges {
-				subnet := element
-				log.Println("#################################################6")
-				log.Println(subnet)
-				IPSecVpnSubnet := model.IPSecVpnSubnet{
-					Subnet: &subnet,
-				}
-				SourceIPSecVpnSubnetList = append(SourceIPSecVpnSubnetList, IPSecVpnSubnet)
-			}
-		}
+func getIPSecVpnSessionsT0Client(connector *client.RestConnector, isGlobalManager bool) t0_ipsec_vpn_services.SessionsClient {
+	if isGlobalManager {
+		return gm_t0_ipsec_vpn_services.NewDefaultSessionsClient(connector)
+	}
+	return t0_ipsec_vpn_services.NewDefaultSessionsClient(connector)
+}
 
-		/// Destination Subnets
-		DestinationIPSecVpnSubnetList := make([]model.IPSecVpnSubnet, 0)
-		if len(destinationRanges) > 0 {
-			for _, element := range destinationRanges {
-				subnet := element
-				log.Println("#################################################6")
-				log.Println(subnet)
-				IPSecVpnSubnet := model.IPSecVpnSubnet{
-					Subnet: &subnet,
-				}
-				DestinationIPSecVpnSubnetList = append(DestinationIPSecVpnSubnetList, IPSecVpnSubnet)
-			}
-		}
+func getNsxtPolicyIPSecVpnSession(connector *client.RestConnector, isGlobalManager bool, isT0 bool, gwID string, localeServiceID string, serviceID string, id string) (*data.StructValue, error) {
+	if isT0 {
+		client := getIPSecVpnSessionsT0Client(connector, isGlobalManager)
+		return client.Get(gwID, localeServiceID, serviceID, id)
+	}
+	client := t1_ipsec_vpn_services.NewDefaultSessionsClient(connector)
+	return client.Get(gwID, localeServiceID, serviceID, id)
+}
 
-		rule_id := newUUID()
-		log.Println("#################################################")
-		elem := model.IPSecVpnRule{
-			Action:       &action,
-			Sources:      SourceIPSecVpnSubnetList,
-			Destinations: DestinationIPSecVpnSubnetList,
-			UniqueId:     &rule_id,
-			Id:           &rule_id,
-		}
-		ruleList = append(ruleList, elem)
-		log.Println("#################################################")
+func patchNsxtPolicyIPSecVpnSession(connector *client.RestConnector, isGlobalManager bool, isT0 bool, gwID string, localeServiceID string, serviceID string, id string, obj *data.StructValue) error {
+	if isT0 {
+		client := getIPSecVpnSessionsT0Client(connector, isGlobalManager)
+		return client.Patch(gwID, localeServiceID, serviceID, id, obj)
 	}
-	return ruleList
+	client := t1_ipsec_vpn_services.NewDefaultSessionsClient(connector)
+	return client.Patch(gwID, localeServiceID, serviceID, id, obj)
 }
 
-func resourceNsxtPolicyIPSecVpnSessionCreate(d *schema.ResourceData, m interface{}) error {
+func deleteNsxtPolicyIPSecVpnSession(connector *client.RestConnector, isGlobalManager bool, isT0 bool, gwID string, localeServiceID string, serviceID string, id string) error {
+	if isT0 {
+		client := getIPSecVpnSessionsT0Client(connector, isGlobalManager)
+		return client.Delete(gwID, localeServiceID, serviceID, id)
+	}
+	client := t1_ipsec_vpn_services.NewDefaultSessionsClient(connector)
+	return client.Delete(gwID, localeServiceID, serviceID, id)
+}
+
+func resourceNsxtPolicyIPSecVpnSessionExists(connector *client.RestConnector, isGlobalManager bool, servicePath string, id string) (bool, error) {
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	_, err := getNsxtPolicyIPSecVpnSession(connector, isGlobalManager, isT0, gwID, localeServiceID, serviceID, id)
+	if err == nil {
+		return true, nil
+	}
 
-	Tier0ID := d.Get("tier0_id").(string)
-	LocaleService := d.Get("locale_service").(string)
-	ServiceID := d.Get("service_id").(string)
+	if isNotFoundError(err) {
+		return false, nil
+	}
 
-	// Initialize resource Id and verify this ID is not yet used
-	id := newUUID()
+	return false, logAPIError("Error retrieving resource", err)
+}
 
+func resourceNsxtPolicyIPSecVpnSessionCreate(d *schema.ResourceData, m interface{}) error {
 	connector := getPolicyConnector(m)
+	isGlobalManager := isPolicyGlobalManager(m)
+
+	servicePath := d.Get("service_path").(string)
+	id := d.Get("nsx_id").(string)
+	if id == "" {
+		id = newUUID()
+	} else {
+		exists, err := resourceNsxtPolicyIPSecVpnSessionExists(connector, isGlobalManager, servicePath, id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("IPSec VPN Session with ID '%s' already exists on service %s", id, servicePath)
+		}
+	}
+
+	if d.Get("authentication_mode").(string) == model.IPSecVpnSession_AUTHENTICATION_MODE_CERTIFICATE {
+		err := validateIPSecVpnSessionLocalEndpointCertificate(connector, isGlobalManager, d.Get("local_endpoint_path").(string))
+		if err != nil {
+			return err
+		}
+	}
 
 	obj, err := getIPSecVPNSessionFromSchema(d)
 	if err != nil {
 		return err
 	}
 
-	client := ipsec_vpn_services.NewDefaultSessionsClient(connector)
-
 	// Create the resource using PATCH
 	log.Printf("[INFO] Creating IPSecVpnSession with ID %s", id)
-
-	err2 := client.Patch(Tier0ID, LocaleService, ServiceID, id, obj)
-
-	if err2 != nil {
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	err = patchNsxtPolicyIPSecVpnSession(connector, isGlobalManager, isT0, gwID, localeServiceID, serviceID, id, obj)
+	if err != nil {
 		return handleCreateError("IPSecVpnSession", id, err)
 	}
 
@@ -502,28 +716,22 @@ func resourceNsxtPolicyIPSecVpnSessionRead(d *schema.ResourceData, m interface{}
 		return fmt.Errorf("Error obtaining IPSecVpnSession ID")
 	}
 
-	Tier0ID := d.Get("tier0_id").(string)
-	LocaleService := d.Get("locale_service").(string)
-	ServiceID := d.Get("service_id").(string)
-
-	client := ipsec_vpn_services.NewDefaultSessionsClient(connector)
-
-	obj, err := client.Get(Tier0ID, LocaleService, ServiceID, id)
-
+	servicePath := d.Get("service_path").(string)
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	if gwID == "" {
+		return fmt.Errorf("Invalid IPSec VPN service path %s", servicePath)
+	}
+	obj, err := getNsxtPolicyIPSecVpnSession(connector, isPolicyGlobalManager(m), isT0, gwID, localeServiceID, serviceID, id)
 	if err != nil {
-		if isNotFoundError(err) {
-			d.SetId("")
-			log.Printf("[DEBUG] VPNSession %s not found", id)
-			return nil
-		}
 		return handleReadError(d, "VPN Session", id, err)
 	}
 
-	interfaceVpn, errs := converter.ConvertToGolang(obj, model.RouteBasedIPSecVpnSessionBindingType())
+	baseObj, errs := converter.ConvertToGolang(obj, model.IPSecVpnSessionBindingType())
 	if len(errs) > 0 {
 		return fmt.Errorf("Error converting VPN Session %s", errs[0])
 	}
-	blockVPN := interfaceVpn.(model.RouteBasedIPSecVpnSession)
+	blockVPN := baseObj.(model.IPSecVpnSession)
+	resourceType := blockVPN.ResourceType
 
 	d.Set("display_name", blockVPN.DisplayName)
 	d.Set("description", blockVPN.Description)
@@ -531,6 +739,69 @@ func resourceNsxtPolicyIPSecVpnSessionRead(d *schema.ResourceData, m interface{}
 	d.Set("nsx_id", blockVPN.Id)
 	d.Set("path", blockVPN.Path)
 	d.Set("revision", blockVPN.Revision)
+	d.Set("vpn_type", resourceType)
+	d.Set("authentication_mode", blockVPN.AuthenticationMode)
+	d.Set("compliance_suite", blockVPN.ComplianceSuite)
+	d.Set("connection_initiation_mode", blockVPN.ConnectionInitiationMode)
+	d.Set("dpd_profile_path", blockVPN.DpdProfilePath)
+	d.Set("enabled", blockVPN.Enabled)
+	d.Set("ike_profile_path", blockVPN.IkeProfilePath)
+	d.Set("local_endpoint_path", blockVPN.LocalEndpointPath)
+	d.Set("tunnel_profile_path", blockVPN.TunnelProfilePath)
+	d.Set("peer_address", blockVPN.PeerAddress)
+	d.Set("peer_id", blockVPN.PeerId)
+
+	if resourceType == model.IPSecVpnSession_RESOURCE_TYPE_ROUTEBASEDIPSECVPNSESSION {
+		routeVPN, errs := converter.ConvertToGolang(obj, model.RouteBasedIPSecVpnSessionBindingType())
+		if len(errs) > 0 {
+			return fmt.Errorf("Error converting VPN Session %s", errs[0])
+		}
+		routeObj := routeVPN.(model.RouteBasedIPSecVpnSession)
+
+		// Deprecated subnets and prefix_length are only populated if used in configuration
+		if _, ok := d.GetOk("subnets"); ok {
+			var subnets []string
+			var prefixLength int64
+			for _, vti := range routeObj.TunnelInterfaces {
+				for _, ipSubnet := range vti.IpSubnets {
+					subnets = append(subnets, ipSubnet.IpAddresses...)
+					if ipSubnet.PrefixLength != nil {
+						prefixLength = *ipSubnet.PrefixLength
+					}
+				}
+			}
+			d.Set("subnets", subnets)
+			d.Set("prefix_length", prefixLength)
+			// tunnel_interface conflicts with deprecated attributes
+			d.Set("tunnel_interface", nil)
+		} else {
+			sessionPath := ""
+			if blockVPN.Path != nil {
+				sessionPath = *blockVPN.Path
+			}
+			err = setIPSecVpnTunnelInterfacesInSchema(d, sessionPath, routeObj.TunnelInterfaces)
+			if err != nil {
+				return handleReadError(d, "VPN Session", id, err)
+			}
+		}
+		d.Set("rule", nil)
+	} else if resourceType == model.IPSecVpnSession_RESOURCE_TYPE_POLICYBASEDIPSECVPNSESSION {
+		policyVPN, errs := converter.ConvertToGolang(obj, model.PolicyBasedIPSecVpnSessionBindingType())
+		if len(errs) > 0 {
+			return fmt.Errorf("Error converting VPN Session %s", errs[0])
+		}
+		policyObj := policyVPN.(model.PolicyBasedIPSecVpnSession)
+
+		d.Set("subnets", nil)
+		d.Set("prefix_length", nil)
+		d.Set("tunnel_interface", nil)
+		err = setIPSecVPNRulesInSchema(d, policyObj.Rules)
+		if err != nil {
+			return handleReadError(d, "VPN Session", id, err)
+		}
+	} else {
+		return fmt.Errorf("Unrecognized VPN Session type %s", resourceType)
+	}
 
 	return nil
 }
@@ -543,50 +814,57 @@ func resourceNsxtPolicyIPSecVpnSessionUpdate(d *schema.ResourceData, m interface
 		return fmt.Errorf("Error obtaining IPSecVpnSession ID")
 	}
 
-	Tier0ID := d.Get("tier0_id").(string)
-	LocaleService := d.Get("locale_service").(string)
-	ServiceID := d.Get("service_id").(string)
+	servicePath := d.Get("service_path").(string)
+
+	if d.Get("authentication_mode").(string) == model.IPSecVpnSession_AUTHENTICATION_MODE_CERTIFICATE {
+		err := validateIPSecVpnSessionLocalEndpointCertificate(connector, isPolicyGlobalManager(m), d.Get("local_endpoint_path").(string))
+		if err != nil {
+			return err
+		}
+	}
 
 	obj, err := getIPSecVPNSessionFromSchema(d)
 	if err != nil {
 		return err
 	}
 
-	client := ipsec_vpn_services.NewDefaultSessionsClient(connector)
-
-	// Create the resource using PATCH
-	log.Printf("[INFO] Creating IPSecVpnSession with ID %s", id)
-
-	err2 := client.Patch(Tier0ID, LocaleService, ServiceID, id, obj)
-
-	if err2 != nil {
+	log.Printf("[INFO] Updating IPSecVpnSession with ID %s", id)
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	err = patchNsxtPolicyIPSecVpnSession(connector, isPolicyGlobalManager(m), isT0, gwID, localeServiceID, serviceID, id, obj)
+	if err != nil {
 		return handleUpdateError("IPSecVpnSession", id, err)
 	}
-	d.SetId(id)
-	d.Set("nsx_id", id)
-	return resourceNsxtPolicyIPSecVpnSessionRead(d, m)
 
+	return resourceNsxtPolicyIPSecVpnSessionRead(d, m)
 }
 
 func resourceNsxtPolicyIPSecVpnSessionDelete(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
 
 	id := d.Id()
 	if id == "" {
 		return fmt.Errorf("Error obtaining IPSecVpnSession ID")
 	}
-	Tier0ID := d.Get("tier0_id").(string)
-	LocaleService := d.Get("locale_service").(string)
-	ServiceID := d.Get("service_id").(string)
-
-	connector := getPolicyConnector(m)
-
-	var err error
-	client := ipsec_vpn_services.NewDefaultSessionsClient(connector)
-	err = client.Delete(Tier0ID, LocaleService, ServiceID, id)
 
+	servicePath := d.Get("service_path").(string)
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	err := deleteNsxtPolicyIPSecVpnSession(connector, isPolicyGlobalManager(m), isT0, gwID, localeServiceID, serviceID, id)
 	if err != nil {
 		return handleDeleteError("IPSecVpnSession", id, err)
 	}
 
 	return nil
 }
+
+func resourceNsxtPolicyIPSecVpnSessionImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importPath := d.Id()
+	servicePath, id := parseVpnServiceChildPolicyPath(importPath, "ipsec-vpn-services", "sessions")
+	if servicePath == "" {
+		return nil, fmt.Errorf("Please provide IPSec VPN Session policy path as an input, for example /infra/tier-0s/<gateway-id>/locale-services/<locale-service-id>/ipsec-vpn-services/<service-id>/sessions/<session-id>")
+	}
+
+	d.Set("service_path", servicePath)
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
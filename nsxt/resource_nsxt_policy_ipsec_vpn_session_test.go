@@ -0,0 +1,835 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var accTestPolicyIPSecVpnSessionHelperName = getAccTestResourceName()
+
+var accTestPolicyIPSecVpnSessionCreateAttributes = map[string]string{
+	"display_name": getAccTestResourceName(),
+	"description":  "terraform created",
+	"peer_address": "18.18.18.19",
+	"peer_id":      "18.18.18.19",
+	"enabled":      "true",
+	"ip_address":   "169.254.152.2",
+	"peer_ip":      "169.254.152.1",
+}
+
+var accTestPolicyIPSecVpnSessionUpdateAttributes = map[string]string{
+	"display_name": getAccTestResourceName(),
+	"description":  "terraform updated",
+	"peer_address": "18.18.18.20",
+	"peer_id":      "18.18.18.20",
+	"enabled":      "false",
+	"ip_address":   "169.254.152.6",
+	"peer_ip":      "169.254.152.5",
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnSession_basic(t *testing.T) {
+	testResourceName := "nsxt_policy_ipsec_vpn_session.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIPSecVpnSessionCheckDestroy(state, accTestPolicyIPSecVpnSessionUpdateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionTemplate(true, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyIPSecVpnSessionCreateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyIPSecVpnSessionCreateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "peer_address", accTestPolicyIPSecVpnSessionCreateAttributes["peer_address"]),
+					resource.TestCheckResourceAttr(testResourceName, "peer_id", accTestPolicyIPSecVpnSessionCreateAttributes["peer_id"]),
+					resource.TestCheckResourceAttr(testResourceName, "enabled", accTestPolicyIPSecVpnSessionCreateAttributes["enabled"]),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.0.ip_subnet.0.ip_addresses.0", accTestPolicyIPSecVpnSessionCreateAttributes["ip_address"]),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.0.peer_ip_addresses.0", accTestPolicyIPSecVpnSessionCreateAttributes["peer_ip"]),
+					resource.TestCheckResourceAttrSet(testResourceName, "tunnel_interface.0.path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "service_path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionTemplate(false, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionUpdateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyIPSecVpnSessionUpdateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyIPSecVpnSessionUpdateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "peer_address", accTestPolicyIPSecVpnSessionUpdateAttributes["peer_address"]),
+					resource.TestCheckResourceAttr(testResourceName, "peer_id", accTestPolicyIPSecVpnSessionUpdateAttributes["peer_id"]),
+					resource.TestCheckResourceAttr(testResourceName, "enabled", accTestPolicyIPSecVpnSessionUpdateAttributes["enabled"]),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.0.ip_subnet.0.ip_addresses.0", accTestPolicyIPSecVpnSessionUpdateAttributes["ip_address"]),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.0.peer_ip_addresses.0", accTestPolicyIPSecVpnSessionUpdateAttributes["peer_ip"]),
+					resource.TestCheckResourceAttrSet(testResourceName, "tunnel_interface.0.path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "service_path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionMinimalistic(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "description", ""),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnSession_tier1(t *testing.T) {
+	testResourceName := "nsxt_policy_ipsec_vpn_session.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIPSecVpnSessionCheckDestroy(state, accTestPolicyIPSecVpnSessionUpdateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionTemplate(true, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyIPSecVpnSessionCreateAttributes["display_name"]),
+					resource.TestCheckResourceAttrSet(testResourceName, "service_path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionTemplate(false, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionUpdateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyIPSecVpnSessionUpdateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "enabled", accTestPolicyIPSecVpnSessionUpdateAttributes["enabled"]),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnSession_importBasic(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_policy_ipsec_vpn_session.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIPSecVpnSessionCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionMinimalistic(false),
+			},
+			{
+				ResourceName:            testResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccNsxtPolicyVpnPathImporterGetID(testResourceName),
+				ImportStateVerifyIgnore: []string{"psk"},
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnSession_bypassRuleAction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNsxtPolicyIPSecVpnSessionPolicyBasedTemplate("BYPASS"),
+				ExpectError: regexp.MustCompile(`expected .*action to be one of \[PROTECT\]`),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnSession_vpnTypeChange(t *testing.T) {
+	testResourceName := "nsxt_policy_ipsec_vpn_session.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIPSecVpnSessionCheckDestroy(state, accTestPolicyIPSecVpnSessionCreateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionPolicyBasedTemplate("PROTECT"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "vpn_type", "PolicyBasedIPSecVpnSession"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionMinimalistic(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "vpn_type", "RouteBasedIPSecVpnSession"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "0"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnSession_vpnTypeChangeCreateBeforeDestroy(t *testing.T) {
+	testResourceName := "nsxt_policy_ipsec_vpn_session.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIPSecVpnSessionCheckDestroy(state, accTestPolicyIPSecVpnSessionCreateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionCreateBeforeDestroyTemplate(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "vpn_type", "RouteBasedIPSecVpnSession"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionCreateBeforeDestroyTemplate(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "vpn_type", "PolicyBasedIPSecVpnSession"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.#", "0"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionCreateBeforeDestroyTemplate(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "vpn_type", "RouteBasedIPSecVpnSession"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnSession_complianceSuite(t *testing.T) {
+	testResourceName := "nsxt_policy_ipsec_vpn_session.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIPSecVpnSessionCheckDestroy(state, accTestPolicyIPSecVpnSessionCreateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNsxtPolicyIPSecVpnSessionComplianceSuiteTemplate("FIPS", `ike_profile_path = "/infra/ipsec-vpn-ike-profiles/nsx-default-l3vpn-ike-profile"`),
+				ExpectError: regexp.MustCompile(`ike_profile_path can not be specified with compliance_suite FIPS`),
+			},
+			{
+				Config:      testAccNsxtPolicyIPSecVpnSessionComplianceSuiteTemplate("CNSA", ""),
+				ExpectError: regexp.MustCompile(`compliance_suite CNSA requires authentication_mode CERTIFICATE`),
+			},
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionComplianceSuiteTemplate("FIPS", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "compliance_suite", "FIPS"),
+					resource.TestCheckResourceAttrSet(testResourceName, "ike_profile_path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "tunnel_profile_path"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnSession_certificate(t *testing.T) {
+	testResourceName := "nsxt_policy_ipsec_vpn_session.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccOnlyLocalManager(t)
+			testAccEnvDefined(t, "NSXT_TEST_CERTIFICATE_NAME")
+		},
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIPSecVpnSessionCheckDestroy(state, accTestPolicyIPSecVpnSessionCreateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionCertificateTemplate(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "authentication_mode", "CERTIFICATE"),
+					resource.TestCheckResourceAttr(testResourceName, "compliance_suite", "FIPS"),
+					resource.TestCheckResourceAttr(testResourceName, "peer_id", "CN=peer.example.com"),
+					resource.TestCheckResourceAttr(testResourceName, "psk", ""),
+					resource.TestCheckResourceAttrPair(testResourceName, "local_endpoint_path", "nsxt_policy_ipsec_vpn_local_endpoint.test", "path"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnSession_pskVersion(t *testing.T) {
+	testResourceName := "nsxt_policy_ipsec_vpn_session.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIPSecVpnSessionCheckDestroy(state, accTestPolicyIPSecVpnSessionCreateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionPskVersionTemplate(1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "psk_version", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "psk", "secret1"),
+				),
+			},
+			{
+				// Only psk_version is changed, psk is sent to NSX again
+				Config: testAccNsxtPolicyIPSecVpnSessionPskVersionTemplate(2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "psk_version", "2"),
+					resource.TestCheckResourceAttr(testResourceName, "psk", "secret1"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnSession_ruleIDs(t *testing.T) {
+	testResourceName := "nsxt_policy_ipsec_vpn_session.test"
+	var firstRuleID, secondRuleID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIPSecVpnSessionCheckDestroy(state, accTestPolicyIPSecVpnSessionCreateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionRulesTemplate([]string{"192.168.10.0/24", "192.168.20.0/24"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "2"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.sequence_number", "10"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.1.sequence_number", "20"),
+					testAccNsxtPolicyIPSecVpnSessionGetRuleID(testResourceName, 0, &firstRuleID),
+					testAccNsxtPolicyIPSecVpnSessionGetRuleID(testResourceName, 1, &secondRuleID),
+				),
+			},
+			{
+				// Modify second rule and append a third one, existing rule IDs are kept
+				Config: testAccNsxtPolicyIPSecVpnSessionRulesTemplate([]string{"192.168.10.0/24", "192.168.21.0/24", "192.168.30.0/24"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "3"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.2.sequence_number", "30"),
+					testAccNsxtPolicyIPSecVpnSessionCheckRuleID(testResourceName, 0, &firstRuleID),
+					testAccNsxtPolicyIPSecVpnSessionCheckRuleID(testResourceName, 1, &secondRuleID),
+					resource.TestCheckResourceAttrSet(testResourceName, "rule.2.nsx_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNsxtPolicyIPSecVpnSessionGetRuleID(resourceName string, index int, ruleID *string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Policy IPSec VPN Session resource %s not found in resources", resourceName)
+		}
+
+		*ruleID = rs.Primary.Attributes[fmt.Sprintf("rule.%d.nsx_id", index)]
+		if *ruleID == "" {
+			return fmt.Errorf("Policy IPSec VPN Session rule %d has no ID", index)
+		}
+		return nil
+	}
+}
+
+func testAccNsxtPolicyIPSecVpnSessionCheckRuleID(resourceName string, index int, ruleID *string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		return resource.TestCheckResourceAttr(resourceName, fmt.Sprintf("rule.%d.nsx_id", index), *ruleID)(state)
+	}
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnSession_multipleTunnelInterfaces(t *testing.T) {
+	testResourceName := "nsxt_policy_ipsec_vpn_session.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIPSecVpnSessionCheckDestroy(state, accTestPolicyIPSecVpnSessionCreateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionTunnelInterfacesTemplate(`
+  tunnel_interface {
+    display_name = "vti1"
+    ip_subnet {
+      ip_addresses  = ["169.254.152.2"]
+      prefix_length = 30
+    }
+  }
+
+  tunnel_interface {
+    display_name = "vti2"
+    ip_subnet {
+      ip_addresses  = ["169.254.153.2"]
+      prefix_length = 30
+    }
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.#", "2"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.0.display_name", "vti1"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.0.peer_ip_addresses.0", "169.254.152.1"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.1.display_name", "vti2"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.1.peer_ip_addresses.0", "169.254.153.1"),
+					resource.TestCheckResourceAttrSet(testResourceName, "tunnel_interface.0.nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "tunnel_interface.1.nsx_id"),
+				),
+			},
+			{
+				// Removal of a tunnel interface is applied
+				Config: testAccNsxtPolicyIPSecVpnSessionTunnelInterfacesTemplate(`
+  tunnel_interface {
+    display_name = "vti1"
+    ip_subnet {
+      ip_addresses  = ["169.254.152.2"]
+      prefix_length = 30
+    }
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.0.display_name", "vti1"),
+				),
+			},
+			{
+				Config:      testAccNsxtPolicyIPSecVpnSessionTunnelInterfacesTemplate(""),
+				ExpectError: regexp.MustCompile(`at least one tunnel_interface is required`),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnSession_dualStackTunnelInterface(t *testing.T) {
+	testResourceName := "nsxt_policy_ipsec_vpn_session.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccOnlyLocalManager(t)
+			// IPv6 tunnel interface addresses are not accepted by earlier NSX versions
+			testAccNSXVersion(t, "3.2.0")
+		},
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIPSecVpnSessionCheckDestroy(state, accTestPolicyIPSecVpnSessionCreateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIPSecVpnSessionTunnelInterfacesTemplate(`
+  tunnel_interface {
+    ip_subnet {
+      ip_addresses  = ["169.254.152.2"]
+      prefix_length = 30
+    }
+    ip_subnet {
+      ip_addresses  = ["fd00:169:254::2"]
+      prefix_length = 126
+    }
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIPSecVpnSessionExists(accTestPolicyIPSecVpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.0.ip_subnet.#", "2"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.0.ip_subnet.1.prefix_length", "126"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.0.peer_ip_addresses.#", "2"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.0.peer_ip_addresses.0", "169.254.152.1"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_interface.0.peer_ip_addresses.1", "fd00:169:254::1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIPSecVpnSession_policyBasedTunnelInterface(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNsxtPolicyIPSecVpnSessionPolicyBasedWithTunnelInterfaceTemplate(),
+				ExpectError: regexp.MustCompile(`tunnel_interface is only relevant for RouteBasedIPSecVpnSession`),
+			},
+		},
+	})
+}
+
+func testAccNsxtPolicyIPSecVpnSessionExists(displayName string, resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+
+		connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Policy IPSec VPN Session resource %s not found in resources", resourceName)
+		}
+
+		resourceID := rs.Primary.ID
+		if resourceID == "" {
+			return fmt.Errorf("Policy IPSec VPN Session resource ID not set in resources")
+		}
+
+		exists, err := resourceNsxtPolicyIPSecVpnSessionExists(connector, testAccIsGlobalManager(), rs.Primary.Attributes["service_path"], resourceID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("Policy IPSec VPN Session %s does not exist", resourceID)
+		}
+
+		return nil
+	}
+}
+
+func testAccNsxtPolicyIPSecVpnSessionCheckDestroy(state *terraform.State, displayName string) error {
+	connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+	for _, rs := range state.RootModule().Resources {
+
+		if rs.Type != "nsxt_policy_ipsec_vpn_session" {
+			continue
+		}
+
+		resourceID := rs.Primary.Attributes["id"]
+		exists, err := resourceNsxtPolicyIPSecVpnSessionExists(connector, testAccIsGlobalManager(), rs.Primary.Attributes["service_path"], resourceID)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return fmt.Errorf("Policy IPSec VPN Session %s still exists", displayName)
+		}
+	}
+	return nil
+}
+
+func testAccNsxtPolicyIPSecVpnSessionPrerequisites(isT0 bool) string {
+	var gwTemplate string
+	gwType := "tier1"
+	if isT0 {
+		gwTemplate = testAccNsxtPolicyTier0WithEdgeClusterTemplate("test", true)
+		gwType = "tier0"
+	} else {
+		gwTemplate = testAccNsxtPolicyTier1WithEdgeClusterTemplate("test", false)
+	}
+	return testAccNsxtPolicyEdgeClusterReadTemplate(getEdgeClusterName()) + gwTemplate + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_service" "test" {
+  display_name = "%s"
+  gateway_path = nsxt_policy_%s_gateway.test.path
+}
+
+resource "nsxt_policy_ipsec_vpn_local_endpoint" "test" {
+  display_name  = "%s"
+  service_path  = nsxt_policy_ipsec_vpn_service.test.path
+  local_address = "20.20.0.10"
+}`, accTestPolicyIPSecVpnSessionHelperName, gwType, accTestPolicyIPSecVpnSessionHelperName)
+}
+
+func testAccNsxtPolicyIPSecVpnSessionTemplate(createFlow bool, isT0 bool) string {
+	var attrMap map[string]string
+	if createFlow {
+		attrMap = accTestPolicyIPSecVpnSessionCreateAttributes
+	} else {
+		attrMap = accTestPolicyIPSecVpnSessionUpdateAttributes
+	}
+	return testAccNsxtPolicyIPSecVpnSessionPrerequisites(isT0) + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_session" "test" {
+  display_name        = "%s"
+  description         = "%s"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "RouteBasedIPSecVpnSession"
+  peer_address        = "%s"
+  peer_id             = "%s"
+  psk                 = "secret1"
+  enabled             = %s
+
+  tunnel_interface {
+    ip_subnet {
+      ip_addresses  = ["%s"]
+      prefix_length = 30
+    }
+  }
+
+  tag {
+    scope = "scope1"
+    tag   = "tag1"
+  }
+}`, attrMap["display_name"], attrMap["description"], attrMap["peer_address"], attrMap["peer_id"], attrMap["enabled"], attrMap["ip_address"])
+}
+
+func testAccNsxtPolicyIPSecVpnSessionMinimalistic(isT0 bool) string {
+	attrMap := accTestPolicyIPSecVpnSessionCreateAttributes
+	return testAccNsxtPolicyIPSecVpnSessionPrerequisites(isT0) + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_session" "test" {
+  display_name        = "%s"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "RouteBasedIPSecVpnSession"
+  peer_address        = "%s"
+  peer_id             = "%s"
+  psk                 = "secret1"
+
+  tunnel_interface {
+    ip_subnet {
+      ip_addresses  = ["%s"]
+      prefix_length = 30
+    }
+  }
+}`, attrMap["display_name"], attrMap["peer_address"], attrMap["peer_id"], attrMap["ip_address"])
+}
+
+func testAccNsxtPolicyIPSecVpnSessionPolicyBasedTemplate(action string) string {
+	attrMap := accTestPolicyIPSecVpnSessionCreateAttributes
+	return testAccNsxtPolicyIPSecVpnSessionPrerequisites(true) + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_session" "test" {
+  display_name        = "%s"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "PolicyBasedIPSecVpnSession"
+  peer_address        = "%s"
+  peer_id             = "%s"
+  psk                 = "secret1"
+
+  rule {
+    sources      = ["192.168.10.0/24"]
+    destinations = ["192.169.10.0/24"]
+    action       = "%s"
+  }
+}`, attrMap["display_name"], attrMap["peer_address"], attrMap["peer_id"], action)
+}
+
+func testAccNsxtPolicyIPSecVpnSessionCreateBeforeDestroyTemplate(policyBased bool) string {
+	attrMap := accTestPolicyIPSecVpnSessionCreateAttributes
+	vpnType := "RouteBasedIPSecVpnSession"
+	vpnConfig := fmt.Sprintf(`
+  tunnel_interface {
+    ip_subnet {
+      ip_addresses  = ["%s"]
+      prefix_length = 30
+    }
+  }`, attrMap["ip_address"])
+	if policyBased {
+		vpnType = "PolicyBasedIPSecVpnSession"
+		vpnConfig = `
+  rule {
+    sources      = ["192.168.10.0/24"]
+    destinations = ["192.169.10.0/24"]
+  }`
+	}
+	return testAccNsxtPolicyIPSecVpnSessionPrerequisites(true) + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_session" "test" {
+  display_name        = "%s"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "%s"
+  peer_address        = "%s"
+  peer_id             = "%s"
+  psk                 = "secret1"
+%s
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}`, attrMap["display_name"], vpnType, attrMap["peer_address"], attrMap["peer_id"], vpnConfig)
+}
+
+func testAccNsxtPolicyIPSecVpnSessionComplianceSuiteTemplate(complianceSuite string, extraAttributes string) string {
+	attrMap := accTestPolicyIPSecVpnSessionCreateAttributes
+	return testAccNsxtPolicyIPSecVpnSessionPrerequisites(true) + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_session" "test" {
+  display_name        = "%s"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "RouteBasedIPSecVpnSession"
+  peer_address        = "%s"
+  peer_id             = "%s"
+  psk                 = "secret1"
+  compliance_suite    = "%s"
+  %s
+
+  tunnel_interface {
+    ip_subnet {
+      ip_addresses  = ["%s"]
+      prefix_length = 30
+    }
+  }
+}`, attrMap["display_name"], attrMap["peer_address"], attrMap["peer_id"], complianceSuite, extraAttributes, attrMap["ip_address"])
+}
+
+func testAccNsxtPolicyIPSecVpnSessionCertificateTemplate() string {
+	attrMap := accTestPolicyIPSecVpnSessionCreateAttributes
+	// The test certificate is expected to be self-signed, thus it also serves as trusted CA
+	return testAccNsxtPolicyEdgeClusterReadTemplate(getEdgeClusterName()) + testAccNsxtPolicyTier0WithEdgeClusterTemplate("test", true) + fmt.Sprintf(`
+data "nsxt_policy_certificate" "test" {
+  display_name = "%s"
+}
+
+resource "nsxt_policy_ipsec_vpn_service" "test" {
+  display_name = "%s"
+  gateway_path = nsxt_policy_tier0_gateway.test.path
+}
+
+resource "nsxt_policy_ipsec_vpn_local_endpoint" "test" {
+  display_name     = "%s"
+  service_path     = nsxt_policy_ipsec_vpn_service.test.path
+  local_address    = "20.20.0.10"
+  certificate_path = data.nsxt_policy_certificate.test.path
+  trust_ca_paths   = [data.nsxt_policy_certificate.test.path]
+}
+
+resource "nsxt_policy_ipsec_vpn_session" "test" {
+  display_name        = "%s"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "RouteBasedIPSecVpnSession"
+  authentication_mode = "CERTIFICATE"
+  compliance_suite    = "FIPS"
+  peer_address        = "%s"
+  peer_id             = "CN=peer.example.com"
+
+  tunnel_interface {
+    ip_subnet {
+      ip_addresses  = ["%s"]
+      prefix_length = 30
+    }
+  }
+}`, getTestCertificateName(false), accTestPolicyIPSecVpnSessionHelperName, accTestPolicyIPSecVpnSessionHelperName, attrMap["display_name"], attrMap["peer_address"], attrMap["ip_address"])
+}
+
+func testAccNsxtPolicyIPSecVpnSessionPskVersionTemplate(pskVersion int) string {
+	attrMap := accTestPolicyIPSecVpnSessionCreateAttributes
+	return testAccNsxtPolicyIPSecVpnSessionPrerequisites(true) + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_session" "test" {
+  display_name        = "%s"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "RouteBasedIPSecVpnSession"
+  peer_address        = "%s"
+  peer_id             = "%s"
+  psk                 = "secret1"
+  psk_version         = %d
+
+  tunnel_interface {
+    ip_subnet {
+      ip_addresses  = ["%s"]
+      prefix_length = 30
+    }
+  }
+}`, attrMap["display_name"], attrMap["peer_address"], attrMap["peer_id"], pskVersion, attrMap["ip_address"])
+}
+
+func testAccNsxtPolicyIPSecVpnSessionRulesTemplate(sources []string) string {
+	attrMap := accTestPolicyIPSecVpnSessionCreateAttributes
+	rules := ""
+	for i, source := range sources {
+		rules += fmt.Sprintf(`
+  rule {
+    sources         = ["%s"]
+    destinations    = ["192.169.10.0/24"]
+    sequence_number = %d
+  }
+`, source, (i+1)*10)
+	}
+	return testAccNsxtPolicyIPSecVpnSessionPrerequisites(true) + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_session" "test" {
+  display_name        = "%s"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "PolicyBasedIPSecVpnSession"
+  peer_address        = "%s"
+  peer_id             = "%s"
+  psk                 = "secret1"
+%s}`, attrMap["display_name"], attrMap["peer_address"], attrMap["peer_id"], rules)
+}
+
+func testAccNsxtPolicyIPSecVpnSessionTunnelInterfacesTemplate(tunnelInterfaces string) string {
+	attrMap := accTestPolicyIPSecVpnSessionCreateAttributes
+	return testAccNsxtPolicyIPSecVpnSessionPrerequisites(true) + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_session" "test" {
+  display_name        = "%s"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "RouteBasedIPSecVpnSession"
+  peer_address        = "%s"
+  peer_id             = "%s"
+  psk                 = "secret1"
+%s
+}`, attrMap["display_name"], attrMap["peer_address"], attrMap["peer_id"], tunnelInterfaces)
+}
+
+func testAccNsxtPolicyIPSecVpnSessionPolicyBasedWithTunnelInterfaceTemplate() string {
+	attrMap := accTestPolicyIPSecVpnSessionCreateAttributes
+	return testAccNsxtPolicyIPSecVpnSessionPrerequisites(true) + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_session" "test" {
+  display_name        = "%s"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "PolicyBasedIPSecVpnSession"
+  peer_address        = "%s"
+  peer_id             = "%s"
+  psk                 = "secret1"
+
+  rule {
+    sources      = ["192.168.10.0/24"]
+    destinations = ["192.169.10.0/24"]
+  }
+
+  tunnel_interface {
+    ip_subnet {
+      ip_addresses  = ["%s"]
+      prefix_length = 30
+    }
+  }
+}`, attrMap["display_name"], attrMap["peer_address"], attrMap["peer_id"], attrMap["ip_address"])
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/global_infra"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 )
@@ -33,7 +34,7 @@ var IPSecVpnTunnelProfileDigestAlgorithms = []string{
 	model.IPSecVpnTunnelProfile_DIGEST_ALGORITHMS_SHA2_512,
 }
 
-var interfaceVIPSecVpnTunnelProfileDhGroups = []string{
+var IPSecVpnTunnelProfileDhGroups = []string{
 	model.IPSecVpnTunnelProfile_DH_GROUPS_GROUP14,
 	model.IPSecVpnTunnelProfile_DH_GROUPS_GROUP2,
 	model.IPSecVpnTunnelProfile_DH_GROUPS_GROUP5,
@@ -44,6 +45,18 @@ var interfaceVIPSecVpnTunnelProfileDhGroups = []string{
 	model.IPSecVpnTunnelProfile_DH_GROUPS_GROUP21,
 }
 
+var IPSecVpnTunnelProfileDfPolicyValues = []string{
+	model.IPSecVpnTunnelProfile_DF_POLICY_COPY,
+	model.IPSecVpnTunnelProfile_DF_POLICY_CLEAR,
+}
+
+var IPSecVpnTunnelProfileAttributeDataTypes = []string{
+	model.AttributeVal_DATA_TYPE_STRING,
+	model.AttributeVal_DATA_TYPE_DATE,
+	model.AttributeVal_DATA_TYPE_INTEGER,
+	model.AttributeVal_DATA_TYPE_BOOLEAN,
+}
+
 func resourceNsxtPolicyIpsecVpnTunnelProfile() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceNsxtPolicyIpsecVpnTunnelProfileCreate,
@@ -69,6 +82,7 @@ func resourceNsxtPolicyIpsecVpnTunnelProfile() *schema.Resource {
 					ValidateFunc: validation.StringInSlice(IPSecVpnTunnelProfileEncryptionAlgorithms, false),
 				},
 				Optional: true,
+				Computed: true,
 			},
 			"digest_algorithms": {
 				Type:        schema.TypeSet,
@@ -78,24 +92,83 @@ func resourceNsxtPolicyIpsecVpnTunnelProfile() *schema.Resource {
 					ValidateFunc: validation.StringInSlice(IPSecVpnTunnelProfileDigestAlgorithms, false),
 				},
 				Optional: true,
+				Computed: true,
 			},
 			"dh_groups": {
 				Type:        schema.TypeSet,
 				Description: "Diffie-Hellman group to be used if PFS is enabled. Default is GROUP14.",
 				Elem: &schema.Schema{
 					Type:         schema.TypeString,
-					ValidateFunc: validation.StringInSlice(interfaceVIPSecVpnTunnelProfileDhGroups, false),
+					ValidateFunc: validation.StringInSlice(IPSecVpnTunnelProfileDhGroups, false),
 				},
 				Optional: true,
+				Computed: true,
+			},
+			"enable_perfect_forward_secrecy": {
+				Type:        schema.TypeBool,
+				Description: "Enable perfect forward secrecy",
+				Optional:    true,
+				Default:     true,
+			},
+			"sa_life_time": {
+				Type:         schema.TypeInt,
+				Description:  "SA life time specifies the expiry time of security association in seconds",
+				Optional:     true,
+				Default:      3600,
+				ValidateFunc: validation.IntBetween(900, 31536000),
+			},
+			"df_policy": {
+				Type:         schema.TypeString,
+				Description:  "Defragmentation policy for the tunnel. COPY copies the defragmentation bit from the inner IP packet, CLEAR ignores it",
+				Optional:     true,
+				Default:      model.IPSecVpnTunnelProfile_DF_POLICY_COPY,
+				ValidateFunc: validation.StringInSlice(IPSecVpnTunnelProfileDfPolicyValues, false),
+			},
+			"extended_attribute": {
+				Type:        schema.TypeList,
+				Description: "Type specific properties, such as encapsulation mode and transform protocol",
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Description: "Attribute key",
+							Required:    true,
+						},
+						"values": {
+							Type:        schema.TypeList,
+							Description: "List of attribute values",
+							Required:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"data_type": {
+							Type:         schema.TypeString,
+							Description:  "Data type of the attribute",
+							Optional:     true,
+							Default:      model.AttributeVal_DATA_TYPE_STRING,
+							ValidateFunc: validation.StringInSlice(IPSecVpnTunnelProfileAttributeDataTypes, false),
+						},
+					},
+				},
 			},
 		},
 	}
 }
 
+func getIPSecVpnTunnelProfilesClient(connector *client.RestConnector, isGlobalManager bool) infra.IpsecVpnTunnelProfilesClient {
+	if isGlobalManager {
+		return global_infra.NewDefaultIpsecVpnTunnelProfilesClient(connector)
+	}
+	return infra.NewDefaultIpsecVpnTunnelProfilesClient(connector)
+}
+
 func resourceNsxtPolicyIpsecVpnTunnelProfileExists(id string, connector *client.RestConnector, isGlobalManager bool) (bool, error) {
 	var err error
 
-	client := infra.NewDefaultIpsecVpnTunnelProfilesClient(connector)
+	client := getIPSecVpnTunnelProfilesClient(connector, isGlobalManager)
 	_, err = client.Get(id)
 
 	if err == nil {
@@ -109,8 +182,69 @@ func resourceNsxtPolicyIpsecVpnTunnelProfileExists(id string, connector *client.
 	return false, logAPIError("Error retrieving resource", err)
 }
 
-func resourceNsxtPolicyIpsecVpnTunnelProfileCreate(d *schema.ResourceData, m interface{}) error {
+func getIpsecVpnTunnelProfileExtendedAttributesFromSchema(d *schema.ResourceData) []model.AttributeVal {
+	var attributes []model.AttributeVal
+	for _, attribute := range d.Get("extended_attribute").([]interface{}) {
+		data := attribute.(map[string]interface{})
+		key := data["key"].(string)
+		dataType := data["data_type"].(string)
+		values := interface2StringList(data["values"].([]interface{}))
+		multivalue := len(values) > 1
+		attributes = append(attributes, model.AttributeVal{
+			Key:        &key,
+			DataType:   &dataType,
+			Values:     values,
+			Multivalue: &multivalue,
+		})
+	}
+	return attributes
+}
 
+func setIpsecVpnTunnelProfileExtendedAttributesInSchema(d *schema.ResourceData, attributes []model.AttributeVal) error {
+	var attributeList []map[string]interface{}
+	for _, attribute := range attributes {
+		elem := make(map[string]interface{})
+		elem["key"] = attribute.Key
+		elem["values"] = attribute.Values
+		elem["data_type"] = attribute.DataType
+		attributeList = append(attributeList, elem)
+	}
+	return d.Set("extended_attribute", attributeList)
+}
+
+func setIpsecVpnTunnelProfileAttributesInSchema(d *schema.ResourceData, obj model.IPSecVpnTunnelProfile) error {
+	d.Set("encryption_algorithms", obj.EncryptionAlgorithms)
+	d.Set("digest_algorithms", obj.DigestAlgorithms)
+	d.Set("dh_groups", obj.DhGroups)
+	d.Set("enable_perfect_forward_secrecy", obj.EnablePerfectForwardSecrecy)
+	d.Set("sa_life_time", obj.SaLifeTime)
+	d.Set("df_policy", obj.DfPolicy)
+	return setIpsecVpnTunnelProfileExtendedAttributesInSchema(d, obj.ExtendedAttributes)
+}
+
+func policyIpsecVpnTunnelProfileFromSchema(d *schema.ResourceData) model.IPSecVpnTunnelProfile {
+	displayName := d.Get("display_name").(string)
+	description := d.Get("description").(string)
+	tags := getPolicyTagsFromSchema(d)
+	enablePfs := d.Get("enable_perfect_forward_secrecy").(bool)
+	saLifeTime := int64(d.Get("sa_life_time").(int))
+	dfPolicy := d.Get("df_policy").(string)
+
+	return model.IPSecVpnTunnelProfile{
+		DisplayName:                 &displayName,
+		Description:                 &description,
+		Tags:                        tags,
+		DhGroups:                    getStringListFromSchemaSet(d, "dh_groups"),
+		DigestAlgorithms:            getStringListFromSchemaSet(d, "digest_algorithms"),
+		EncryptionAlgorithms:        getStringListFromSchemaSet(d, "encryption_algorithms"),
+		EnablePerfectForwardSecrecy: &enablePfs,
+		SaLifeTime:                  &saLifeTime,
+		DfPolicy:                    &dfPolicy,
+		ExtendedAttributes:          getIpsecVpnTunnelProfileExtendedAttributesFromSchema(d),
+	}
+}
+
+func resourceNsxtPolicyIpsecVpnTunnelProfileCreate(d *schema.ResourceData, m interface{}) error {
 	connector := getPolicyConnector(m)
 
 	// Initialize resource Id and verify this ID is not yet used
@@ -119,25 +253,12 @@ func resourceNsxtPolicyIpsecVpnTunnelProfileCreate(d *schema.ResourceData, m int
 		return err
 	}
 
-	displayName := d.Get("display_name").(string)
-	description := d.Get("description").(string)
-
-	DhGroups := getStringListFromSchemaSet(d, "dh_groups")
-	DigestAlgorithms := getStringListFromSchemaSet(d, "digest_algorithms")
-	EncryptionAlgorithms := getStringListFromSchemaSet(d, "encryption_algorithms")
-
-	obj := model.IPSecVpnTunnelProfile{
-		DisplayName:          &displayName,
-		Description:          &description,
-		DhGroups:             DhGroups,
-		DigestAlgorithms:     DigestAlgorithms,
-		EncryptionAlgorithms: EncryptionAlgorithms,
-	}
+	obj := policyIpsecVpnTunnelProfileFromSchema(d)
 
 	// Create the resource using PATCH
 	log.Printf("[INFO] Creating IpsecVpnTunnelProfile with ID %s", id)
 
-	client := infra.NewDefaultIpsecVpnTunnelProfilesClient(connector)
+	client := getIPSecVpnTunnelProfilesClient(connector, isPolicyGlobalManager(m))
 	err = client.Patch(id, obj)
 
 	if err != nil {
@@ -158,7 +279,7 @@ func resourceNsxtPolicyIpsecVpnTunnelProfileRead(d *schema.ResourceData, m inter
 	}
 
 	var obj model.IPSecVpnTunnelProfile
-	client := infra.NewDefaultIpsecVpnTunnelProfilesClient(connector)
+	client := getIPSecVpnTunnelProfilesClient(connector, isPolicyGlobalManager(m))
 	var err error
 	obj, err = client.Get(id)
 	if err != nil {
@@ -170,7 +291,7 @@ func resourceNsxtPolicyIpsecVpnTunnelProfileRead(d *schema.ResourceData, m inter
 	d.Set("nsx_id", id)
 	d.Set("path", obj.Path)
 	d.Set("revision", obj.Revision)
-	return nil
+	return setIpsecVpnTunnelProfileAttributesInSchema(d, obj)
 }
 
 func resourceNsxtPolicyIpsecVpnTunnelProfileUpdate(d *schema.ResourceData, m interface{}) error {
@@ -180,20 +301,12 @@ func resourceNsxtPolicyIpsecVpnTunnelProfileUpdate(d *schema.ResourceData, m int
 	if id == "" {
 		return fmt.Errorf("Error obtaining IpsecVpnTunnelProfile ID")
 	}
-	displayName := d.Get("display_name").(string)
-	description := d.Get("description").(string)
-	DhGroups := getStringListFromSchemaSet(d, "dh_groups")
-	DigestAlgorithms := getStringListFromSchemaSet(d, "digest_algorithms")
-	EncryptionAlgorithms := getStringListFromSchemaSet(d, "encryption_algorithms")
-	obj := model.IPSecVpnTunnelProfile{
-		DisplayName:          &displayName,
-		Description:          &description,
-		DhGroups:             DhGroups,
-		DigestAlgorithms:     DigestAlgorithms,
-		EncryptionAlgorithms: EncryptionAlgorithms,
-	}
+	obj := policyIpsecVpnTunnelProfileFromSchema(d)
+	revision := int64(d.Get("revision").(int))
+	obj.Revision = &revision
+
 	var err error
-	client := infra.NewDefaultIpsecVpnTunnelProfilesClient(connector)
+	client := getIPSecVpnTunnelProfilesClient(connector, isPolicyGlobalManager(m))
 	err = client.Patch(id, obj)
 
 	if err != nil {
@@ -204,7 +317,6 @@ func resourceNsxtPolicyIpsecVpnTunnelProfileUpdate(d *schema.ResourceData, m int
 	return resourceNsxtPolicyIpsecVpnTunnelProfileRead(d, m)
 
 }
-
 func resourceNsxtPolicyIpsecVpnTunnelProfileDelete(d *schema.ResourceData, m interface{}) error {
 	id := d.Id()
 	if id == "" {
@@ -213,7 +325,7 @@ func resourceNsxtPolicyIpsecVpnTunnelProfileDelete(d *schema.ResourceData, m int
 
 	connector := getPolicyConnector(m)
 	var err error
-	client := infra.NewDefaultIpsecVpnTunnelProfilesClient(connector)
+	client := getIPSecVpnTunnelProfilesClient(connector, isPolicyGlobalManager(m))
 	err = client.Delete(id)
 
 	if err != nil {
@@ -0,0 +1,201 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var accTestPolicyIpsecVpnTunnelProfileCreateAttributes = map[string]string{
+	"display_name":                   getAccTestResourceName(),
+	"description":                    "terraform created",
+	"encryption_algorithms":          "AES_GCM_128",
+	"dh_groups":                      "GROUP14",
+	"enable_perfect_forward_secrecy": "true",
+	"sa_life_time":                   "3600",
+	"df_policy":                      "COPY",
+}
+
+var accTestPolicyIpsecVpnTunnelProfileUpdateAttributes = map[string]string{
+	"display_name":                   getAccTestResourceName(),
+	"description":                    "terraform updated",
+	"encryption_algorithms":          "AES_256",
+	"dh_groups":                      "GROUP19",
+	"enable_perfect_forward_secrecy": "false",
+	"sa_life_time":                   "7200",
+	"df_policy":                      "CLEAR",
+}
+
+func TestAccResourceNsxtPolicyIpsecVpnTunnelProfile_basic(t *testing.T) {
+	testResourceName := "nsxt_policy_ipsec_vpn_tunnel_profile.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIpsecVpnTunnelProfileCheckDestroy(state, accTestPolicyIpsecVpnTunnelProfileUpdateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIpsecVpnTunnelProfileTemplate(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIpsecVpnTunnelProfileExists(accTestPolicyIpsecVpnTunnelProfileCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyIpsecVpnTunnelProfileCreateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyIpsecVpnTunnelProfileCreateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "encryption_algorithms.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "digest_algorithms.#", "0"),
+					resource.TestCheckResourceAttr(testResourceName, "dh_groups.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "enable_perfect_forward_secrecy", accTestPolicyIpsecVpnTunnelProfileCreateAttributes["enable_perfect_forward_secrecy"]),
+					resource.TestCheckResourceAttr(testResourceName, "sa_life_time", accTestPolicyIpsecVpnTunnelProfileCreateAttributes["sa_life_time"]),
+					resource.TestCheckResourceAttr(testResourceName, "df_policy", accTestPolicyIpsecVpnTunnelProfileCreateAttributes["df_policy"]),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIpsecVpnTunnelProfileTemplate(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIpsecVpnTunnelProfileExists(accTestPolicyIpsecVpnTunnelProfileUpdateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyIpsecVpnTunnelProfileUpdateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyIpsecVpnTunnelProfileUpdateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "encryption_algorithms.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "digest_algorithms.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "dh_groups.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "enable_perfect_forward_secrecy", accTestPolicyIpsecVpnTunnelProfileUpdateAttributes["enable_perfect_forward_secrecy"]),
+					resource.TestCheckResourceAttr(testResourceName, "sa_life_time", accTestPolicyIpsecVpnTunnelProfileUpdateAttributes["sa_life_time"]),
+					resource.TestCheckResourceAttr(testResourceName, "df_policy", accTestPolicyIpsecVpnTunnelProfileUpdateAttributes["df_policy"]),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyIpsecVpnTunnelProfileMinimalistic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyIpsecVpnTunnelProfileExists(accTestPolicyIpsecVpnTunnelProfileUpdateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "description", ""),
+					resource.TestCheckResourceAttr(testResourceName, "enable_perfect_forward_secrecy", "true"),
+					resource.TestCheckResourceAttr(testResourceName, "sa_life_time", "3600"),
+					resource.TestCheckResourceAttr(testResourceName, "df_policy", "COPY"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyIpsecVpnTunnelProfile_importBasic(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_policy_ipsec_vpn_tunnel_profile.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyIpsecVpnTunnelProfileCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyIpsecVpnTunnelProfileMinimalistic(),
+			},
+			{
+				ResourceName:      testResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNsxtPolicyIpsecVpnTunnelProfileExists(displayName string, resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+
+		connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Policy IpsecVpnTunnelProfile resource %s not found in resources", resourceName)
+		}
+
+		resourceID := rs.Primary.ID
+		if resourceID == "" {
+			return fmt.Errorf("Policy IpsecVpnTunnelProfile resource ID not set in resources")
+		}
+
+		exists, err := resourceNsxtPolicyIpsecVpnTunnelProfileExists(resourceID, connector, testAccIsGlobalManager())
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("Policy IpsecVpnTunnelProfile %s does not exist", resourceID)
+		}
+
+		return nil
+	}
+}
+
+func testAccNsxtPolicyIpsecVpnTunnelProfileCheckDestroy(state *terraform.State, displayName string) error {
+	connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+	for _, rs := range state.RootModule().Resources {
+
+		if rs.Type != "nsxt_policy_ipsec_vpn_tunnel_profile" {
+			continue
+		}
+
+		resourceID := rs.Primary.Attributes["id"]
+		exists, err := resourceNsxtPolicyIpsecVpnTunnelProfileExists(resourceID, connector, testAccIsGlobalManager())
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return fmt.Errorf("Policy IpsecVpnTunnelProfile %s still exists", displayName)
+		}
+	}
+	return nil
+}
+
+func testAccNsxtPolicyIpsecVpnTunnelProfileTemplate(createFlow bool) string {
+	var attrMap map[string]string
+	digestAlgorithms := "[]"
+	if createFlow {
+		attrMap = accTestPolicyIpsecVpnTunnelProfileCreateAttributes
+	} else {
+		attrMap = accTestPolicyIpsecVpnTunnelProfileUpdateAttributes
+		digestAlgorithms = "[\"SHA2_256\"]"
+	}
+	return fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_tunnel_profile" "test" {
+  display_name                   = "%s"
+  description                    = "%s"
+  encryption_algorithms          = ["%s"]
+  digest_algorithms              = %s
+  dh_groups                      = ["%s"]
+  enable_perfect_forward_secrecy = %s
+  sa_life_time                   = %s
+  df_policy                      = "%s"
+
+  tag {
+    scope = "scope1"
+    tag   = "tag1"
+  }
+}`, attrMap["display_name"], attrMap["description"], attrMap["encryption_algorithms"], digestAlgorithms, attrMap["dh_groups"], attrMap["enable_perfect_forward_secrecy"], attrMap["sa_life_time"], attrMap["df_policy"])
+}
+
+func testAccNsxtPolicyIpsecVpnTunnelProfileMinimalistic() string {
+	return fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_tunnel_profile" "test" {
+  display_name = "%s"
+}`, accTestPolicyIpsecVpnTunnelProfileUpdateAttributes["display_name"])
+}
@@ -0,0 +1,263 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	t0_locale_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services"
+	t1_locale_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/locale_services"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+var L2VpnServiceModeValues = []string{
+	model.L2VPNService_MODE_SERVER,
+	model.L2VPNService_MODE_CLIENT,
+}
+
+func resourceNsxtPolicyL2VpnService() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNsxtPolicyL2VpnServiceCreate,
+		Read:   resourceNsxtPolicyL2VpnServiceRead,
+		Update: resourceNsxtPolicyL2VpnServiceUpdate,
+		Delete: resourceNsxtPolicyL2VpnServiceDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceNsxtPolicyL2VpnServiceImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"nsx_id":            getNsxIDSchema(),
+			"path":              getPathSchema(),
+			"display_name":      getDisplayNameSchema(),
+			"description":       getDescriptionSchema(),
+			"revision":          getRevisionSchema(),
+			"tag":               getTagsSchema(),
+			"gateway_path":      getPolicyPathSchema(true, true, "Policy path for Tier0 or Tier1 gateway"),
+			"locale_service_id": getComputedLocaleServiceIDSchema(),
+			"mode": {
+				Type:         schema.TypeString,
+				Description:  "L2VPN service mode. In SERVER mode, the service accepts connections from L2VPN clients, in CLIENT mode it connects to a remote L2VPN server",
+				Optional:     true,
+				Default:      model.L2VPNService_MODE_SERVER,
+				ValidateFunc: validation.StringInSlice(L2VpnServiceModeValues, false),
+			},
+			"enable_hub": {
+				Type:        schema.TypeBool,
+				Description: "Relevant in SERVER mode only. If true, traffic from any client is replicated to all other clients",
+				Optional:    true,
+				Default:     false,
+			},
+			"encapsulation_ip_pool": {
+				Type:        schema.TypeList,
+				Description: "IP pool to allocate local and peer endpoint IPs for L2VPN session logical tap",
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateCidr(),
+				},
+			},
+		},
+	}
+}
+
+func getNsxtPolicyL2VpnService(connector *client.RestConnector, isT0 bool, gwID string, localeServiceID string, id string) (model.L2VPNService, error) {
+	if isT0 {
+		client := t0_locale_services.NewDefaultL2vpnServicesClient(connector)
+		return client.Get(gwID, localeServiceID, id)
+	}
+	client := t1_locale_services.NewDefaultL2vpnServicesClient(connector)
+	return client.Get(gwID, localeServiceID, id)
+}
+
+func patchNsxtPolicyL2VpnService(connector *client.RestConnector, isT0 bool, gwID string, localeServiceID string, id string, obj model.L2VPNService) error {
+	if isT0 {
+		client := t0_locale_services.NewDefaultL2vpnServicesClient(connector)
+		return client.Patch(gwID, localeServiceID, id, obj)
+	}
+	client := t1_locale_services.NewDefaultL2vpnServicesClient(connector)
+	return client.Patch(gwID, localeServiceID, id, obj)
+}
+
+func deleteNsxtPolicyL2VpnService(connector *client.RestConnector, isT0 bool, gwID string, localeServiceID string, id string) error {
+	if isT0 {
+		client := t0_locale_services.NewDefaultL2vpnServicesClient(connector)
+		return client.Delete(gwID, localeServiceID, id)
+	}
+	client := t1_locale_services.NewDefaultL2vpnServicesClient(connector)
+	return client.Delete(gwID, localeServiceID, id)
+}
+
+func resourceNsxtPolicyL2VpnServiceExists(connector *client.RestConnector, isT0 bool, gwID string, localeServiceID string, id string) (bool, error) {
+	_, err := getNsxtPolicyL2VpnService(connector, isT0, gwID, localeServiceID, id)
+	if err == nil {
+		return true, nil
+	}
+
+	if isNotFoundError(err) {
+		return false, nil
+	}
+
+	return false, logAPIError("Error retrieving resource", err)
+}
+
+func policyL2VpnServiceFromSchema(d *schema.ResourceData) model.L2VPNService {
+	displayName := d.Get("display_name").(string)
+	description := d.Get("description").(string)
+	tags := getPolicyTagsFromSchema(d)
+	mode := d.Get("mode").(string)
+	enableHub := d.Get("enable_hub").(bool)
+
+	return model.L2VPNService{
+		DisplayName: &displayName,
+		Description: &description,
+		Tags:        tags,
+		Mode:        &mode,
+		EnableHub:   &enableHub,
+		EncapIpPool: getStringListFromSchemaList(d, "encapsulation_ip_pool"),
+	}
+}
+
+func resourceNsxtPolicyL2VpnServiceCreate(d *schema.ResourceData, m interface{}) error {
+	if isPolicyGlobalManager(m) {
+		return localManagerOnlyError()
+	}
+
+	connector := getPolicyConnector(m)
+
+	gwPath := d.Get("gateway_path").(string)
+	isT0, gwID := parseGatewayPolicyPath(gwPath)
+	if gwID == "" {
+		return fmt.Errorf("gateway_path is not valid")
+	}
+
+	localeServiceID, err := getPolicyGatewayLocaleServiceID(connector, isT0, gwID)
+	if err != nil {
+		return err
+	}
+
+	id := d.Get("nsx_id").(string)
+	if id == "" {
+		id = newUUID()
+	} else {
+		exists, err := resourceNsxtPolicyL2VpnServiceExists(connector, isT0, gwID, localeServiceID, id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("L2VPN Service with ID '%s' already exists on Gateway %s", id, gwID)
+		}
+	}
+
+	obj := policyL2VpnServiceFromSchema(d)
+
+	log.Printf("[INFO] Creating L2VPN Service with ID %s", id)
+	err = patchNsxtPolicyL2VpnService(connector, isT0, gwID, localeServiceID, id, obj)
+	if err != nil {
+		return handleCreateError("L2VPN Service", id, err)
+	}
+
+	d.SetId(id)
+	d.Set("nsx_id", id)
+	d.Set("locale_service_id", localeServiceID)
+
+	return resourceNsxtPolicyL2VpnServiceRead(d, m)
+}
+
+func resourceNsxtPolicyL2VpnServiceRead(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	if id == "" {
+		return fmt.Errorf("Error obtaining L2VPN Service ID")
+	}
+
+	gwPath := d.Get("gateway_path").(string)
+	isT0, gwID := parseGatewayPolicyPath(gwPath)
+	localeServiceID := d.Get("locale_service_id").(string)
+	if gwID == "" || localeServiceID == "" {
+		return fmt.Errorf("Error obtaining Gateway id or Locale Service id")
+	}
+
+	obj, err := getNsxtPolicyL2VpnService(connector, isT0, gwID, localeServiceID, id)
+	if err != nil {
+		return handleReadError(d, "L2VPN Service", id, err)
+	}
+
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	setPolicyTagsInSchema(d, obj.Tags)
+	d.Set("nsx_id", id)
+	d.Set("path", obj.Path)
+	d.Set("revision", obj.Revision)
+	d.Set("mode", obj.Mode)
+	d.Set("enable_hub", obj.EnableHub)
+	d.Set("encapsulation_ip_pool", obj.EncapIpPool)
+
+	return nil
+}
+
+func resourceNsxtPolicyL2VpnServiceUpdate(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	gwPath := d.Get("gateway_path").(string)
+	isT0, gwID := parseGatewayPolicyPath(gwPath)
+	localeServiceID := d.Get("locale_service_id").(string)
+	if id == "" || gwID == "" || localeServiceID == "" {
+		return fmt.Errorf("Error obtaining Gateway id or Locale Service id")
+	}
+
+	obj := policyL2VpnServiceFromSchema(d)
+	revision := int64(d.Get("revision").(int))
+	obj.Revision = &revision
+
+	err := patchNsxtPolicyL2VpnService(connector, isT0, gwID, localeServiceID, id, obj)
+	if err != nil {
+		return handleUpdateError("L2VPN Service", id, err)
+	}
+
+	return resourceNsxtPolicyL2VpnServiceRead(d, m)
+}
+
+func resourceNsxtPolicyL2VpnServiceDelete(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	gwPath := d.Get("gateway_path").(string)
+	isT0, gwID := parseGatewayPolicyPath(gwPath)
+	localeServiceID := d.Get("locale_service_id").(string)
+	if id == "" || gwID == "" || localeServiceID == "" {
+		return fmt.Errorf("Error obtaining Gateway id or Locale Service id")
+	}
+
+	err := deleteNsxtPolicyL2VpnService(connector, isT0, gwID, localeServiceID, id)
+	if err != nil {
+		return handleDeleteError("L2VPN Service", id, err)
+	}
+
+	return nil
+}
+
+func resourceNsxtPolicyL2VpnServiceImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importPath := d.Id()
+	isT0, gwID, localeServiceID, id := parseL2VpnServicePolicyPath(importPath)
+	if gwID == "" {
+		return nil, fmt.Errorf("Please provide L2VPN Service policy path as an input, for example /infra/tier-0s/<gateway-id>/locale-services/<locale-service-id>/l2vpn-services/<service-id>")
+	}
+
+	gwType := "tier-1s"
+	if isT0 {
+		gwType = "tier-0s"
+	}
+	d.Set("gateway_path", fmt.Sprintf("/infra/%s/%s", gwType, gwID))
+	d.Set("locale_service_id", localeServiceID)
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
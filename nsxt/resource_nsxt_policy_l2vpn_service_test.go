@@ -0,0 +1,196 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var accTestPolicyL2VpnServiceCreateAttributes = map[string]string{
+	"display_name":          getAccTestResourceName(),
+	"description":           "terraform created",
+	"enable_hub":            "true",
+	"encapsulation_ip_pool": "192.168.10.0/24",
+}
+
+var accTestPolicyL2VpnServiceUpdateAttributes = map[string]string{
+	"display_name":          getAccTestResourceName(),
+	"description":           "terraform updated",
+	"enable_hub":            "false",
+	"encapsulation_ip_pool": "192.168.20.0/24",
+}
+
+func TestAccResourceNsxtPolicyL2VpnService_basic(t *testing.T) {
+	testResourceName := "nsxt_policy_l2vpn_service.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyL2VpnServiceCheckDestroy(state, accTestPolicyL2VpnServiceUpdateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyL2VpnServiceTemplate(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyL2VpnServiceExists(accTestPolicyL2VpnServiceCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyL2VpnServiceCreateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyL2VpnServiceCreateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "mode", "SERVER"),
+					resource.TestCheckResourceAttr(testResourceName, "enable_hub", accTestPolicyL2VpnServiceCreateAttributes["enable_hub"]),
+					resource.TestCheckResourceAttr(testResourceName, "encapsulation_ip_pool.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "encapsulation_ip_pool.0", accTestPolicyL2VpnServiceCreateAttributes["encapsulation_ip_pool"]),
+					resource.TestCheckResourceAttrSet(testResourceName, "gateway_path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "locale_service_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyL2VpnServiceTemplate(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyL2VpnServiceExists(accTestPolicyL2VpnServiceUpdateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyL2VpnServiceUpdateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyL2VpnServiceUpdateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "mode", "SERVER"),
+					resource.TestCheckResourceAttr(testResourceName, "enable_hub", accTestPolicyL2VpnServiceUpdateAttributes["enable_hub"]),
+					resource.TestCheckResourceAttr(testResourceName, "encapsulation_ip_pool.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "encapsulation_ip_pool.0", accTestPolicyL2VpnServiceUpdateAttributes["encapsulation_ip_pool"]),
+					resource.TestCheckResourceAttrSet(testResourceName, "gateway_path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "locale_service_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyL2VpnServiceMinimalistic("SERVER"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyL2VpnServiceExists(accTestPolicyL2VpnServiceCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "description", ""),
+					resource.TestCheckResourceAttr(testResourceName, "mode", "SERVER"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyL2VpnService_importBasic(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_policy_l2vpn_service.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyL2VpnServiceCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyL2VpnServiceMinimalistic("CLIENT"),
+			},
+			{
+				ResourceName:      testResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccNsxtPolicyVpnPathImporterGetID(testResourceName),
+			},
+		},
+	})
+}
+
+func testAccNsxtPolicyL2VpnServiceExists(displayName string, resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+
+		connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Policy L2VPN Service resource %s not found in resources", resourceName)
+		}
+
+		resourceID := rs.Primary.ID
+		if resourceID == "" {
+			return fmt.Errorf("Policy L2VPN Service resource ID not set in resources")
+		}
+		isT0, gwID := parseGatewayPolicyPath(rs.Primary.Attributes["gateway_path"])
+		localeServiceID := rs.Primary.Attributes["locale_service_id"]
+
+		exists, err := resourceNsxtPolicyL2VpnServiceExists(connector, isT0, gwID, localeServiceID, resourceID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("Policy L2VPN Service %s does not exist", resourceID)
+		}
+
+		return nil
+	}
+}
+
+func testAccNsxtPolicyL2VpnServiceCheckDestroy(state *terraform.State, displayName string) error {
+	connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+	for _, rs := range state.RootModule().Resources {
+
+		if rs.Type != "nsxt_policy_l2vpn_service" {
+			continue
+		}
+
+		resourceID := rs.Primary.Attributes["id"]
+		isT0, gwID := parseGatewayPolicyPath(rs.Primary.Attributes["gateway_path"])
+		localeServiceID := rs.Primary.Attributes["locale_service_id"]
+		exists, err := resourceNsxtPolicyL2VpnServiceExists(connector, isT0, gwID, localeServiceID, resourceID)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return fmt.Errorf("Policy L2VPN Service %s still exists", displayName)
+		}
+	}
+	return nil
+}
+
+func testAccNsxtPolicyL2VpnServiceTemplate(createFlow bool) string {
+	var attrMap map[string]string
+	if createFlow {
+		attrMap = accTestPolicyL2VpnServiceCreateAttributes
+	} else {
+		attrMap = accTestPolicyL2VpnServiceUpdateAttributes
+	}
+	return testAccNsxtPolicyIPSecVpnServicePrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_l2vpn_service" "test" {
+  display_name          = "%s"
+  description           = "%s"
+  gateway_path          = nsxt_policy_tier0_gateway.test.path
+  mode                  = "SERVER"
+  enable_hub            = %s
+  encapsulation_ip_pool = ["%s"]
+
+  tag {
+    scope = "scope1"
+    tag   = "tag1"
+  }
+}`, attrMap["display_name"], attrMap["description"], attrMap["enable_hub"], attrMap["encapsulation_ip_pool"])
+}
+
+func testAccNsxtPolicyL2VpnServiceMinimalistic(mode string) string {
+	return testAccNsxtPolicyIPSecVpnServicePrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_l2vpn_service" "test" {
+  display_name = "%s"
+  gateway_path = nsxt_policy_tier0_gateway.test.path
+  mode         = "%s"
+}`, accTestPolicyL2VpnServiceCreateAttributes["display_name"], mode)
+}
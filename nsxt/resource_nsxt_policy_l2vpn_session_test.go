@@ -0,0 +1,261 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var accTestPolicyL2VpnSessionHelperName = getAccTestResourceName()
+
+var accTestPolicyL2VpnSessionCreateAttributes = map[string]string{
+	"display_name": getAccTestResourceName(),
+	"description":  "terraform created",
+	"enabled":      "true",
+}
+
+var accTestPolicyL2VpnSessionUpdateAttributes = map[string]string{
+	"display_name": getAccTestResourceName(),
+	"description":  "terraform updated",
+	"enabled":      "false",
+}
+
+func TestAccResourceNsxtPolicyL2VpnSession_basic(t *testing.T) {
+	testResourceName := "nsxt_policy_l2vpn_session.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyL2VpnSessionCheckDestroy(state, accTestPolicyL2VpnSessionUpdateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyL2VpnSessionTemplate(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyL2VpnSessionExists(accTestPolicyL2VpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyL2VpnSessionCreateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyL2VpnSessionCreateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "enabled", accTestPolicyL2VpnSessionCreateAttributes["enabled"]),
+					resource.TestCheckResourceAttr(testResourceName, "transport_tunnels.#", "1"),
+					resource.TestCheckResourceAttrSet(testResourceName, "service_path"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_encapsulation.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_encapsulation.0.protocol", "GRE"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyL2VpnSessionTemplate(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyL2VpnSessionExists(accTestPolicyL2VpnSessionUpdateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyL2VpnSessionUpdateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyL2VpnSessionUpdateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "enabled", accTestPolicyL2VpnSessionUpdateAttributes["enabled"]),
+					resource.TestCheckResourceAttr(testResourceName, "transport_tunnels.#", "1"),
+					resource.TestCheckResourceAttrSet(testResourceName, "service_path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyL2VpnSessionMinimalistic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyL2VpnSessionExists(accTestPolicyL2VpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "description", ""),
+					resource.TestCheckResourceAttr(testResourceName, "enabled", "true"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyL2VpnSession_importBasic(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_policy_l2vpn_session.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyL2VpnSessionCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyL2VpnSessionMinimalistic(),
+			},
+			{
+				ResourceName:      testResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccNsxtPolicyVpnPathImporterGetID(testResourceName),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyL2VpnSession_nsxID(t *testing.T) {
+	testResourceName := "nsxt_policy_l2vpn_session.test"
+	nsxID := getAccTestResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyL2VpnSessionCheckDestroy(state, accTestPolicyL2VpnSessionCreateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyL2VpnSessionNsxIDTemplate(nsxID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyL2VpnSessionExists(accTestPolicyL2VpnSessionCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "nsx_id", nsxID),
+					resource.TestCheckResourceAttr(testResourceName, "id", nsxID),
+				),
+			},
+		},
+	})
+}
+
+func testAccNsxtPolicyL2VpnSessionExists(displayName string, resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+
+		connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Policy L2VPN Session resource %s not found in resources", resourceName)
+		}
+
+		resourceID := rs.Primary.ID
+		if resourceID == "" {
+			return fmt.Errorf("Policy L2VPN Session resource ID not set in resources")
+		}
+
+		exists, err := resourceNsxtPolicyL2VPNSessionExists(connector, rs.Primary.Attributes["service_path"], resourceID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("Policy L2VPN Session %s does not exist", resourceID)
+		}
+
+		return nil
+	}
+}
+
+func testAccNsxtPolicyL2VpnSessionCheckDestroy(state *terraform.State, displayName string) error {
+	connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+	for _, rs := range state.RootModule().Resources {
+
+		if rs.Type != "nsxt_policy_l2vpn_session" {
+			continue
+		}
+
+		resourceID := rs.Primary.Attributes["id"]
+		exists, err := resourceNsxtPolicyL2VPNSessionExists(connector, rs.Primary.Attributes["service_path"], resourceID)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return fmt.Errorf("Policy L2VPN Session %s still exists", displayName)
+		}
+	}
+	return nil
+}
+
+func testAccNsxtPolicyL2VpnSessionPrerequisites() string {
+	return testAccNsxtPolicyIPSecVpnServicePrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_ipsec_vpn_service" "test" {
+  display_name = "%s"
+  gateway_path = nsxt_policy_tier0_gateway.test.path
+}
+
+resource "nsxt_policy_l2vpn_service" "test" {
+  display_name = "%s"
+  gateway_path = nsxt_policy_tier0_gateway.test.path
+  mode         = "SERVER"
+}
+
+resource "nsxt_policy_ipsec_vpn_local_endpoint" "test" {
+  display_name  = "%s"
+  service_path  = nsxt_policy_ipsec_vpn_service.test.path
+  local_address = "20.20.0.10"
+}
+
+resource "nsxt_policy_ipsec_vpn_session" "test" {
+  display_name        = "%s"
+  service_path        = nsxt_policy_ipsec_vpn_service.test.path
+  local_endpoint_path = nsxt_policy_ipsec_vpn_local_endpoint.test.path
+  vpn_type            = "RouteBasedIPSecVpnSession"
+  peer_address        = "18.18.18.19"
+  peer_id             = "18.18.18.19"
+  psk                 = "secret1"
+
+  tunnel_interface {
+    ip_subnet {
+      ip_addresses  = ["169.254.152.2"]
+      prefix_length = 30
+    }
+  }
+}`, accTestPolicyL2VpnSessionHelperName, accTestPolicyL2VpnSessionHelperName, accTestPolicyL2VpnSessionHelperName, accTestPolicyL2VpnSessionHelperName)
+}
+
+func testAccNsxtPolicyL2VpnSessionTemplate(createFlow bool) string {
+	var attrMap map[string]string
+	if createFlow {
+		attrMap = accTestPolicyL2VpnSessionCreateAttributes
+	} else {
+		attrMap = accTestPolicyL2VpnSessionUpdateAttributes
+	}
+	return testAccNsxtPolicyL2VpnSessionPrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_l2vpn_session" "test" {
+  display_name      = "%s"
+  description       = "%s"
+  service_path      = nsxt_policy_l2vpn_service.test.path
+  transport_tunnels = [nsxt_policy_ipsec_vpn_session.test.path]
+  enabled           = %s
+
+  tunnel_encapsulation {
+    protocol = "GRE"
+  }
+
+  tag {
+    scope = "scope1"
+    tag   = "tag1"
+  }
+}`, attrMap["display_name"], attrMap["description"], attrMap["enabled"])
+}
+
+func testAccNsxtPolicyL2VpnSessionMinimalistic() string {
+	return testAccNsxtPolicyL2VpnSessionPrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_l2vpn_session" "test" {
+  display_name      = "%s"
+  service_path      = nsxt_policy_l2vpn_service.test.path
+  transport_tunnels = [nsxt_policy_ipsec_vpn_session.test.path]
+}`, accTestPolicyL2VpnSessionCreateAttributes["display_name"])
+}
+
+func testAccNsxtPolicyL2VpnSessionNsxIDTemplate(nsxID string) string {
+	return testAccNsxtPolicyL2VpnSessionPrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_l2vpn_session" "test" {
+  nsx_id            = "%s"
+  display_name      = "%s"
+  service_path      = nsxt_policy_l2vpn_service.test.path
+  transport_tunnels = [nsxt_policy_ipsec_vpn_session.test.path]
+}`, nsxID, accTestPolicyL2VpnSessionCreateAttributes["display_name"])
+}
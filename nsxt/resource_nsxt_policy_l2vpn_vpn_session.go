@@ -8,23 +8,25 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	//     "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-
-	// gm_infra "github.com/vmware/vsphere-automation-sdk-go/services/nsxt-gm/global_infra"
-	// gm_model "github.com/vmware/vsphere-automation-sdk-go/services/nsxt-gm/model"
-	// "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
-	l2vpn_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services/l2vpn_services"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	t0_l2vpn_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services/l2vpn_services"
+	t1_l2vpn_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/locale_services/l2vpn_services"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 )
 
+var L2VpnTunnelEncapsulationProtocolValues = []string{
+	model.L2VPNTunnelEncapsulation_PROTOCOL_GRE,
+}
+
 func resourceNsxtPolicyL2VPNSession() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Create: resourceNsxtPolicyL2VPNSessionCreate,
 		Read:   resourceNsxtPolicyL2VPNSessionRead,
 		Update: resourceNsxtPolicyL2VPNSessionUpdate,
 		Delete: resourceNsxtPolicyL2VPNSessionDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceNsxtPolicyL2VPNSessionImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -34,23 +36,12 @@ func resourceNsxtPolicyL2VPNSession() *schema.Resource {
 			"description":  getDescriptionSchema(),
 			"revision":     getRevisionSchema(),
 			"tag":          getTagsSchema(),
-			"tier0_id": {
-				Type:        schema.TypeString,
-				Description: "Policy path referencing Local endpoint.",
-				Optional:    true,
-				Default:     "vmc",
-			},
-			"locale_service": {
-				Type:        schema.TypeString,
-				Description: "Local_service",
-				Optional:    true,
-				Default:     "default",
-			},
-			"service_id": {
-				Type:        schema.TypeString,
-				Description: "Policy path referencing Local endpoint.",
-				Optional:    true,
-				Default:     "default",
+			"service_path": {
+				Type:         schema.TypeString,
+				Description:  "Policy path for L2VPN service on Tier0 or Tier1 gateway",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateVpnServicePolicyPath("l2vpn-services"),
 			},
 			"transport_tunnels": {
 				Type:        schema.TypeList,
@@ -60,42 +51,174 @@ func resourceNsxtPolicyL2VPNSession() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable/Disable L2VPN session",
+				Optional:    true,
+				Default:     true,
+			},
+			"tunnel_encapsulation": {
+				Type:        schema.TypeList,
+				Description: "Tunnel encapsulation config, applicable in CLIENT mode",
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"local_endpoint_address": {
+							Type:         schema.TypeString,
+							Description:  "IP Address of the local tunnel port",
+							Optional:     true,
+							ValidateFunc: validation.IsIPv4Address,
+						},
+						"peer_endpoint_address": {
+							Type:         schema.TypeString,
+							Description:  "IP Address of the peer tunnel port",
+							Optional:     true,
+							ValidateFunc: validation.IsIPv4Address,
+						},
+						"protocol": {
+							Type:         schema.TypeString,
+							Description:  "Encapsulation protocol used by the tunnel",
+							Optional:     true,
+							Default:      model.L2VPNTunnelEncapsulation_PROTOCOL_GRE,
+							ValidateFunc: validation.StringInSlice(L2VpnTunnelEncapsulationProtocolValues, false),
+						},
+					},
+				},
+			},
 		},
 	}
+
+	// service_path replaced tier0_id, locale_service and service_id attributes in version 1
+	resource.SchemaVersion = 1
+	resource.StateUpgraders = getVpnSessionStateUpgraders(resource, "l2vpn-services")
+
+	return resource
 }
 
-func resourceNsxtPolicyL2VPNSessionCreate(d *schema.ResourceData, m interface{}) error {
-	connector := getPolicyConnector(m)
+func getNsxtPolicyL2VPNSession(connector *client.RestConnector, isT0 bool, gwID string, localeServiceID string, serviceID string, id string) (model.L2VPNSession, error) {
+	if isT0 {
+		client := t0_l2vpn_services.NewDefaultSessionsClient(connector)
+		return client.Get(gwID, localeServiceID, serviceID, id)
+	}
+	client := t1_l2vpn_services.NewDefaultSessionsClient(connector)
+	return client.Get(gwID, localeServiceID, serviceID, id)
+}
 
-	Tier0ID := d.Get("tier0_id").(string)
-	LocaleService := d.Get("locale_service").(string)
-	ServiceID := d.Get("service_id").(string)
-	TransportTunnel := getStringListFromSchemaList(d, "transport_tunnels")
+func patchNsxtPolicyL2VPNSession(connector *client.RestConnector, isT0 bool, gwID string, localeServiceID string, serviceID string, id string, obj model.L2VPNSession) error {
+	if isT0 {
+		client := t0_l2vpn_services.NewDefaultSessionsClient(connector)
+		return client.Patch(gwID, localeServiceID, serviceID, id, obj)
+	}
+	client := t1_l2vpn_services.NewDefaultSessionsClient(connector)
+	return client.Patch(gwID, localeServiceID, serviceID, id, obj)
+}
 
-	// Initialize resource Id and verify this ID is not yet used
-	id := "l2vpn_id"
-	var err error
-	if err != nil {
-		return err
+func deleteNsxtPolicyL2VPNSession(connector *client.RestConnector, isT0 bool, gwID string, localeServiceID string, serviceID string, id string) error {
+	if isT0 {
+		client := t0_l2vpn_services.NewDefaultSessionsClient(connector)
+		return client.Delete(gwID, localeServiceID, serviceID, id)
+	}
+	client := t1_l2vpn_services.NewDefaultSessionsClient(connector)
+	return client.Delete(gwID, localeServiceID, serviceID, id)
+}
+
+func resourceNsxtPolicyL2VPNSessionExists(connector *client.RestConnector, servicePath string, id string) (bool, error) {
+	isT0, gwID, localeServiceID, serviceID := parseL2VpnServicePolicyPath(servicePath)
+	_, err := getNsxtPolicyL2VPNSession(connector, isT0, gwID, localeServiceID, serviceID, id)
+	if err == nil {
+		return true, nil
+	}
+
+	if isNotFoundError(err) {
+		return false, nil
+	}
+
+	return false, logAPIError("Error retrieving resource", err)
+}
+
+func getL2VPNTunnelEncapsulationFromSchema(d *schema.ResourceData) *model.L2VPNTunnelEncapsulation {
+	encapsulations := d.Get("tunnel_encapsulation").([]interface{})
+	if len(encapsulations) == 0 || encapsulations[0] == nil {
+		return nil
+	}
+
+	data := encapsulations[0].(map[string]interface{})
+	protocol := data["protocol"].(string)
+	encapsulation := model.L2VPNTunnelEncapsulation{
+		Protocol: &protocol,
+	}
+	localAddress := data["local_endpoint_address"].(string)
+	if localAddress != "" {
+		encapsulation.LocalEndpointAddress = &localAddress
+	}
+	peerAddress := data["peer_endpoint_address"].(string)
+	if peerAddress != "" {
+		encapsulation.PeerEndpointAddress = &peerAddress
+	}
+
+	return &encapsulation
+}
+
+func setL2VPNTunnelEncapsulationInSchema(d *schema.ResourceData, encapsulation *model.L2VPNTunnelEncapsulation) error {
+	var encapsulationList []map[string]interface{}
+	if encapsulation != nil {
+		elem := make(map[string]interface{})
+		elem["local_endpoint_address"] = encapsulation.LocalEndpointAddress
+		elem["peer_endpoint_address"] = encapsulation.PeerEndpointAddress
+		elem["protocol"] = encapsulation.Protocol
+		encapsulationList = append(encapsulationList, elem)
 	}
 
+	return d.Set("tunnel_encapsulation", encapsulationList)
+}
+
+func policyL2VPNSessionFromSchema(d *schema.ResourceData) model.L2VPNSession {
 	displayName := d.Get("display_name").(string)
 	description := d.Get("description").(string)
 	tags := getPolicyTagsFromSchema(d)
+	enabled := d.Get("enabled").(bool)
+
+	return model.L2VPNSession{
+		DisplayName:         &displayName,
+		Description:         &description,
+		Tags:                tags,
+		Enabled:             &enabled,
+		TransportTunnels:    getStringListFromSchemaList(d, "transport_tunnels"),
+		TunnelEncapsulation: getL2VPNTunnelEncapsulationFromSchema(d),
+	}
+}
 
-	obj := model.L2VPNSession{
-		DisplayName:      &displayName,
-		Description:      &description,
-		Tags:             tags,
-		TransportTunnels: TransportTunnel,
+func resourceNsxtPolicyL2VPNSessionCreate(d *schema.ResourceData, m interface{}) error {
+	if isPolicyGlobalManager(m) {
+		return localManagerOnlyError()
 	}
 
-	// Create the resource using PATCH
-	log.Printf("[INFO] Creating L2VPNSession with ID %s", id)
+	connector := getPolicyConnector(m)
+
+	servicePath := d.Get("service_path").(string)
 
-	client := l2vpn_services.NewDefaultSessionsClient(connector)
-	err = client.Patch(Tier0ID, LocaleService, ServiceID, id, obj)
+	// Initialize resource Id and verify this ID is not yet used
+	id := d.Get("nsx_id").(string)
+	if id == "" {
+		id = newUUID()
+	} else {
+		exists, err := resourceNsxtPolicyL2VPNSessionExists(connector, servicePath, id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("L2VPNSession with ID '%s' already exists on service %s", id, servicePath)
+		}
+	}
 
+	obj := policyL2VPNSessionFromSchema(d)
+
+	// Create the resource using PATCH
+	log.Printf("[INFO] Creating L2VPNSession with ID %s", id)
+	isT0, gwID, localeServiceID, serviceID := parseL2VpnServicePolicyPath(servicePath)
+	err := patchNsxtPolicyL2VPNSession(connector, isT0, gwID, localeServiceID, serviceID, id, obj)
 	if err != nil {
 		return handleCreateError("L2VPNSession", id, err)
 	}
@@ -108,19 +231,18 @@ func resourceNsxtPolicyL2VPNSessionCreate(d *schema.ResourceData, m interface{})
 
 func resourceNsxtPolicyL2VPNSessionRead(d *schema.ResourceData, m interface{}) error {
 	connector := getPolicyConnector(m)
-	Tier0ID := d.Get("tier0_id").(string)
-	LocaleService := d.Get("locale_service").(string)
-	ServiceID := d.Get("service_id").(string)
+
 	id := d.Id()
 	if id == "" {
 		return fmt.Errorf("Error obtaining L2VPNSession ID")
 	}
 
-	var obj model.L2VPNSession
-
-	client := l2vpn_services.NewDefaultSessionsClient(connector)
-	var err error
-	obj, err = client.Get(Tier0ID, LocaleService, ServiceID, id)
+	servicePath := d.Get("service_path").(string)
+	isT0, gwID, localeServiceID, serviceID := parseL2VpnServicePolicyPath(servicePath)
+	if gwID == "" {
+		return fmt.Errorf("Invalid L2VPN service path %s", servicePath)
+	}
+	obj, err := getNsxtPolicyL2VPNSession(connector, isT0, gwID, localeServiceID, serviceID, id)
 	if err != nil {
 		return handleReadError(d, "L2VPNSession", id, err)
 	}
@@ -131,70 +253,62 @@ func resourceNsxtPolicyL2VPNSessionRead(d *schema.ResourceData, m interface{}) e
 	d.Set("nsx_id", id)
 	d.Set("path", obj.Path)
 	d.Set("revision", obj.Revision)
+	d.Set("enabled", obj.Enabled)
+	d.Set("transport_tunnels", obj.TransportTunnels)
 
-	//    <!SET_OBJ_ATTRS_IN_SCHEMA!>
-
-	return nil
+	return setL2VPNTunnelEncapsulationInSchema(d, obj.TunnelEncapsulation)
 }
 
 func resourceNsxtPolicyL2VPNSessionUpdate(d *schema.ResourceData, m interface{}) error {
 	connector := getPolicyConnector(m)
-	Tier0ID := d.Get("tier0_id").(string)
-	LocaleService := d.Get("locale_service").(string)
-	ServiceID := d.Get("service_id").(string)
-	TransportTunnel := getStringListFromSchemaList(d, "transport_tunnels")
 
 	id := d.Id()
 	if id == "" {
 		return fmt.Errorf("Error obtaining L2VPNSession ID")
 	}
 
-	// Read the rest of the configured parameters
-	description := d.Get("description").(string)
-	displayName := d.Get("display_name").(string)
-	tags := getPolicyTagsFromSchema(d)
-
-	obj := model.L2VPNSession{
-		DisplayName:      &displayName,
-		Description:      &description,
-		Tags:             tags,
-		TransportTunnels: TransportTunnel,
-	}
+	obj := policyL2VPNSessionFromSchema(d)
+	revision := int64(d.Get("revision").(int))
+	obj.Revision = &revision
 
 	// Update the resource using PATCH
-	var err error
-
-	client := l2vpn_services.NewDefaultSessionsClient(connector)
-	err = client.Patch(Tier0ID, LocaleService, ServiceID, id, obj)
-
+	servicePath := d.Get("service_path").(string)
+	isT0, gwID, localeServiceID, serviceID := parseL2VpnServicePolicyPath(servicePath)
+	err := patchNsxtPolicyL2VPNSession(connector, isT0, gwID, localeServiceID, serviceID, id, obj)
 	if err != nil {
 		return handleUpdateError("L2VPNSession", id, err)
 	}
 
 	return resourceNsxtPolicyL2VPNSessionRead(d, m)
-
 }
 
 func resourceNsxtPolicyL2VPNSessionDelete(d *schema.ResourceData, m interface{}) error {
-
-	Tier0ID := d.Get("tier0_id").(string)
-	LocaleService := d.Get("locale_service").(string)
-	ServiceID := d.Get("service_id").(string)
+	connector := getPolicyConnector(m)
 
 	id := d.Id()
 	if id == "" {
 		return fmt.Errorf("Error obtaining L2VPNSession ID")
 	}
 
-	connector := getPolicyConnector(m)
-	var err error
-
-	client := l2vpn_services.NewDefaultSessionsClient(connector)
-	err = client.Delete(Tier0ID, LocaleService, ServiceID, id)
-
+	servicePath := d.Get("service_path").(string)
+	isT0, gwID, localeServiceID, serviceID := parseL2VpnServicePolicyPath(servicePath)
+	err := deleteNsxtPolicyL2VPNSession(connector, isT0, gwID, localeServiceID, serviceID, id)
 	if err != nil {
 		return handleDeleteError("L2VPNSession", id, err)
 	}
 
 	return nil
 }
+
+func resourceNsxtPolicyL2VPNSessionImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importPath := d.Id()
+	servicePath, id := parseVpnServiceChildPolicyPath(importPath, "l2vpn-services", "sessions")
+	if servicePath == "" {
+		return nil, fmt.Errorf("Please provide L2VPN Session policy path as an input, for example /infra/tier-0s/<gateway-id>/locale-services/<locale-service-id>/l2vpn-services/<service-id>/sessions/<session-id>")
+	}
+
+	d.Set("service_path", servicePath)
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,496 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	t0_locale_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+var l3VpnSessionTypeValues = []string{
+	model.L3VpnSession_RESOURCE_TYPE_POLICYBASEDL3VPNSESSION,
+	model.L3VpnSession_RESOURCE_TYPE_ROUTEBASEDL3VPNSESSION,
+}
+
+var l3VpnDhGroupValues = []string{
+	model.L3Vpn_DH_GROUPS_GROUP2,
+	model.L3Vpn_DH_GROUPS_GROUP5,
+	model.L3Vpn_DH_GROUPS_GROUP14,
+	model.L3Vpn_DH_GROUPS_GROUP15,
+	model.L3Vpn_DH_GROUPS_GROUP16,
+}
+
+var l3VpnIkeVersionValues = []string{
+	model.L3Vpn_IKE_VERSION_V1,
+	model.L3Vpn_IKE_VERSION_V2,
+	model.L3Vpn_IKE_VERSION_FLEX,
+}
+
+var l3VpnDigestAlgorithmValues = []string{
+	model.L3Vpn_IKE_DIGEST_ALGORITHMS_SHA1,
+	model.L3Vpn_IKE_DIGEST_ALGORITHMS_SHA2_256,
+}
+
+var l3VpnEncryptionAlgorithmValues = []string{
+	model.L3Vpn_IKE_ENCRYPTION_ALGORITHMS_128,
+	model.L3Vpn_IKE_ENCRYPTION_ALGORITHMS_256,
+	model.L3Vpn_IKE_ENCRYPTION_ALGORITHMS_GCM_128,
+	model.L3Vpn_IKE_ENCRYPTION_ALGORITHMS_GCM_192,
+	model.L3Vpn_IKE_ENCRYPTION_ALGORITHMS_GCM_256,
+}
+
+func resourceNsxtPolicyL3Vpn() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNsxtPolicyL3VpnCreate,
+		Read:   resourceNsxtPolicyL3VpnRead,
+		Update: resourceNsxtPolicyL3VpnUpdate,
+		Delete: resourceNsxtPolicyL3VpnDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceNsxtPolicyL3VpnImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"nsx_id":            getNsxIDSchema(),
+			"path":              getPathSchema(),
+			"display_name":      getDisplayNameSchema(),
+			"description":       getDescriptionSchema(),
+			"revision":          getRevisionSchema(),
+			"tag":               getTagsSchema(),
+			"gateway_path":      getPolicyPathSchema(true, true, "Policy path for Tier0 gateway"),
+			"locale_service_id": getComputedLocaleServiceIDSchema(),
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable/Disable the L3Vpn",
+				Optional:    true,
+				Default:     true,
+			},
+			"local_address": {
+				Type:         schema.TypeString,
+				Description:  "IPv4 address of local gateway, one of available local addresses of L3Vpn context",
+				Required:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"remote_public_address": {
+				Type:         schema.TypeString,
+				Description:  "Public IPv4 address of remote gateway",
+				Required:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"remote_private_address": {
+				Type:         schema.TypeString,
+				Description:  "Private IPv4 address of remote gateway, used to resolve conflicts when remote site is behind NAT. Default is remote public address",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"passphrases": {
+				Type:        schema.TypeList,
+				Description: "IPSec pre-shared keys used for authentication",
+				Optional:    true,
+				Sensitive:   true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ike_version": {
+				Type:         schema.TypeString,
+				Description:  "IKE protocol version to be used. IKE-Flex will initiate IKE-V2 and responds to both IKE-V1 and IKE-V2",
+				Optional:     true,
+				Default:      model.L3Vpn_IKE_VERSION_V2,
+				ValidateFunc: validation.StringInSlice(l3VpnIkeVersionValues, false),
+			},
+			"ike_encryption_algorithms":    getL3VpnAlgorithmsSchema("Encryption algorithms used during IKE negotiation", l3VpnEncryptionAlgorithmValues),
+			"ike_digest_algorithms":        getL3VpnAlgorithmsSchema("Digest algorithms used during IKE negotiation", l3VpnDigestAlgorithmValues),
+			"tunnel_encryption_algorithms": getL3VpnAlgorithmsSchema("Encryption algorithms used during tunnel negotiation", l3VpnEncryptionAlgorithmValues),
+			"tunnel_digest_algorithms":     getL3VpnAlgorithmsSchema("Digest algorithms used during tunnel negotiation", l3VpnDigestAlgorithmValues),
+			"dh_groups":                    getL3VpnAlgorithmsSchema("Diffie-Hellman groups used if PFS is enabled", l3VpnDhGroupValues),
+			"enable_perfect_forward_secrecy": {
+				Type:        schema.TypeBool,
+				Description: "Enable perfect forward secrecy",
+				Optional:    true,
+				Default:     true,
+			},
+			"session_type": {
+				Type:         schema.TypeString,
+				Description:  "L3Vpn session type",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(l3VpnSessionTypeValues, false),
+			},
+			"tunnel_subnet": {
+				Type:        schema.TypeList,
+				Description: "Tunnel interface (VTI) subnet, relevant for route based session only",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_addresses": {
+							Type:        schema.TypeList,
+							Description: "IPv4 addresses of the tunnel interface",
+							Required:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.IsIPv4Address,
+							},
+						},
+						"prefix_length": {
+							Type:         schema.TypeInt,
+							Description:  "Subnet prefix length",
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 31),
+						},
+					},
+				},
+			},
+			"default_rule_logging": {
+				Type:        schema.TypeBool,
+				Description: "Enable logging for the default rule of the tunnel interface, relevant for route based session only",
+				Optional:    true,
+				Default:     false,
+			},
+			"force_whitelisting": {
+				Type:        schema.TypeBool,
+				Description: "Set default firewall rule action of the tunnel interface to DROP instead of ALLOW, relevant for route based session only",
+				Optional:    true,
+				Default:     false,
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Description: "Protect rules, relevant for policy based session only",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: getL3VpnRuleElemSchema(),
+				},
+			},
+		},
+	}
+}
+
+func getL3VpnAlgorithmsSchema(description string, values []string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Description: description,
+		Optional:    true,
+		Computed:    true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice(values, false),
+		},
+	}
+}
+
+func getNsxtPolicyL3Vpn(connector *client.RestConnector, gwID string, localeServiceID string, id string) (model.L3Vpn, error) {
+	client := t0_locale_services.NewDefaultL3vpnsClient(connector)
+	return client.Get(gwID, localeServiceID, id)
+}
+
+func resourceNsxtPolicyL3VpnExists(connector *client.RestConnector, gwID string, localeServiceID string, id string) (bool, error) {
+	_, err := getNsxtPolicyL3Vpn(connector, gwID, localeServiceID, id)
+	if err == nil {
+		return true, nil
+	}
+
+	if isNotFoundError(err) {
+		return false, nil
+	}
+
+	return false, logAPIError("Error retrieving resource", err)
+}
+
+func getL3VpnSessionFromSchema(d *schema.ResourceData) (*data.StructValue, error) {
+	converter := bindings.NewTypeConverter()
+	converter.SetMode(bindings.REST)
+
+	sessionType := d.Get("session_type").(string)
+	if sessionType == model.L3VpnSession_RESOURCE_TYPE_ROUTEBASEDL3VPNSESSION {
+		defaultRuleLogging := d.Get("default_rule_logging").(bool)
+		forceWhitelisting := d.Get("force_whitelisting").(bool)
+		var tunnelSubnets []model.TunnelSubnet
+		for _, subnet := range d.Get("tunnel_subnet").([]interface{}) {
+			subnetData := subnet.(map[string]interface{})
+			prefixLength := int64(subnetData["prefix_length"].(int))
+			tunnelSubnets = append(tunnelSubnets, model.TunnelSubnet{
+				IpAddresses:  interfaceListToStringList(subnetData["ip_addresses"].([]interface{})),
+				PrefixLength: &prefixLength,
+			})
+		}
+		session := model.RouteBasedL3VpnSession{
+			ResourceType:       sessionType,
+			DefaultRuleLogging: &defaultRuleLogging,
+			ForceWhitelisting:  &forceWhitelisting,
+			TunnelSubnets:      tunnelSubnets,
+		}
+		dataValue, errs := converter.ConvertToVapi(session, model.RouteBasedL3VpnSessionBindingType())
+		if errs != nil {
+			return nil, errs[0]
+		}
+		return dataValue.(*data.StructValue), nil
+	}
+
+	session := model.PolicyBasedL3VpnSession{
+		ResourceType: sessionType,
+		Rules:        getL3VpnRulesFromList(d.Get("rule").([]interface{}), model.L3VpnRule_ACTION_PROTECT),
+	}
+	dataValue, errs := converter.ConvertToVapi(session, model.PolicyBasedL3VpnSessionBindingType())
+	if errs != nil {
+		return nil, errs[0]
+	}
+	return dataValue.(*data.StructValue), nil
+}
+
+func setL3VpnSessionInSchema(d *schema.ResourceData, sessionValue *data.StructValue) error {
+	if sessionValue == nil {
+		return nil
+	}
+
+	converter := bindings.NewTypeConverter()
+	converter.SetMode(bindings.REST)
+
+	baseObj, errs := converter.ConvertToGolang(sessionValue, model.L3VpnSessionBindingType())
+	if errs != nil {
+		return errs[0]
+	}
+	sessionType := baseObj.(model.L3VpnSession).ResourceType
+	d.Set("session_type", sessionType)
+
+	if sessionType == model.L3VpnSession_RESOURCE_TYPE_ROUTEBASEDL3VPNSESSION {
+		routeObj, errs := converter.ConvertToGolang(sessionValue, model.RouteBasedL3VpnSessionBindingType())
+		if errs != nil {
+			return errs[0]
+		}
+		session := routeObj.(model.RouteBasedL3VpnSession)
+		var subnetList []map[string]interface{}
+		for _, subnet := range session.TunnelSubnets {
+			elem := make(map[string]interface{})
+			elem["ip_addresses"] = subnet.IpAddresses
+			elem["prefix_length"] = subnet.PrefixLength
+			subnetList = append(subnetList, elem)
+		}
+		d.Set("tunnel_subnet", subnetList)
+		d.Set("default_rule_logging", session.DefaultRuleLogging)
+		d.Set("force_whitelisting", session.ForceWhitelisting)
+		d.Set("rule", nil)
+		return nil
+	}
+
+	policyObj, errs := converter.ConvertToGolang(sessionValue, model.PolicyBasedL3VpnSessionBindingType())
+	if errs != nil {
+		return errs[0]
+	}
+	session := policyObj.(model.PolicyBasedL3VpnSession)
+	d.Set("tunnel_subnet", nil)
+	return d.Set("rule", getL3VpnRulesList(session.Rules))
+}
+
+func policyL3VpnFromSchema(d *schema.ResourceData) (model.L3Vpn, error) {
+	displayName := d.Get("display_name").(string)
+	description := d.Get("description").(string)
+	tags := getPolicyTagsFromSchema(d)
+	enabled := d.Get("enabled").(bool)
+	localAddress := d.Get("local_address").(string)
+	remotePublicAddress := d.Get("remote_public_address").(string)
+	ikeVersion := d.Get("ike_version").(string)
+	enablePfs := d.Get("enable_perfect_forward_secrecy").(bool)
+
+	session, err := getL3VpnSessionFromSchema(d)
+	if err != nil {
+		return model.L3Vpn{}, err
+	}
+
+	obj := model.L3Vpn{
+		DisplayName:                 &displayName,
+		Description:                 &description,
+		Tags:                        tags,
+		Enabled:                     &enabled,
+		LocalAddress:                &localAddress,
+		RemotePublicAddress:         &remotePublicAddress,
+		IkeVersion:                  &ikeVersion,
+		EnablePerfectForwardSecrecy: &enablePfs,
+		IkeEncryptionAlgorithms:     getStringListFromSchemaSet(d, "ike_encryption_algorithms"),
+		IkeDigestAlgorithms:         getStringListFromSchemaSet(d, "ike_digest_algorithms"),
+		TunnelEncryptionAlgorithms:  getStringListFromSchemaSet(d, "tunnel_encryption_algorithms"),
+		TunnelDigestAlgorithms:      getStringListFromSchemaSet(d, "tunnel_digest_algorithms"),
+		DhGroups:                    getStringListFromSchemaSet(d, "dh_groups"),
+		Passphrases:                 interfaceListToStringList(d.Get("passphrases").([]interface{})),
+		L3vpnSession:                session,
+	}
+
+	remotePrivateAddress := d.Get("remote_private_address").(string)
+	if remotePrivateAddress != "" {
+		obj.RemotePrivateAddress = &remotePrivateAddress
+	}
+
+	return obj, nil
+}
+
+func resourceNsxtPolicyL3VpnCreate(d *schema.ResourceData, m interface{}) error {
+	if isPolicyGlobalManager(m) {
+		return localManagerOnlyError()
+	}
+
+	connector := getPolicyConnector(m)
+
+	gwPath := d.Get("gateway_path").(string)
+	isT0, gwID := parseGatewayPolicyPath(gwPath)
+	if gwID == "" {
+		return fmt.Errorf("gateway_path is not valid")
+	}
+	if !isT0 {
+		return fmt.Errorf("Tier0 gateway path expected, got %s", gwPath)
+	}
+
+	localeServiceID, err := getPolicyGatewayLocaleServiceID(connector, isT0, gwID)
+	if err != nil {
+		return err
+	}
+
+	id := d.Get("nsx_id").(string)
+	if id == "" {
+		id = newUUID()
+	} else {
+		exists, err := resourceNsxtPolicyL3VpnExists(connector, gwID, localeServiceID, id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("L3Vpn with ID '%s' already exists on Gateway %s", id, gwID)
+		}
+	}
+
+	obj, err := policyL3VpnFromSchema(d)
+	if err != nil {
+		return handleCreateError("L3Vpn", id, err)
+	}
+
+	log.Printf("[INFO] Creating L3Vpn with ID %s", id)
+	client := t0_locale_services.NewDefaultL3vpnsClient(connector)
+	err = client.Patch(gwID, localeServiceID, id, obj)
+	if err != nil {
+		return handleCreateError("L3Vpn", id, err)
+	}
+
+	d.SetId(id)
+	d.Set("nsx_id", id)
+	d.Set("locale_service_id", localeServiceID)
+
+	return resourceNsxtPolicyL3VpnRead(d, m)
+}
+
+func resourceNsxtPolicyL3VpnRead(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	if id == "" {
+		return fmt.Errorf("Error obtaining L3Vpn ID")
+	}
+
+	gwPath := d.Get("gateway_path").(string)
+	_, gwID := parseGatewayPolicyPath(gwPath)
+	localeServiceID := d.Get("locale_service_id").(string)
+	if gwID == "" || localeServiceID == "" {
+		return fmt.Errorf("Error obtaining Gateway id or Locale Service id")
+	}
+
+	obj, err := getNsxtPolicyL3Vpn(connector, gwID, localeServiceID, id)
+	if err != nil {
+		return handleReadError(d, "L3Vpn", id, err)
+	}
+
+	// NOTE: passphrases are not returned on API responses
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	setPolicyTagsInSchema(d, obj.Tags)
+	d.Set("nsx_id", id)
+	d.Set("path", obj.Path)
+	d.Set("revision", obj.Revision)
+	d.Set("enabled", obj.Enabled)
+	d.Set("local_address", obj.LocalAddress)
+	d.Set("remote_public_address", obj.RemotePublicAddress)
+	d.Set("remote_private_address", obj.RemotePrivateAddress)
+	d.Set("ike_version", obj.IkeVersion)
+	d.Set("enable_perfect_forward_secrecy", obj.EnablePerfectForwardSecrecy)
+	d.Set("ike_encryption_algorithms", obj.IkeEncryptionAlgorithms)
+	d.Set("ike_digest_algorithms", obj.IkeDigestAlgorithms)
+	d.Set("tunnel_encryption_algorithms", obj.TunnelEncryptionAlgorithms)
+	d.Set("tunnel_digest_algorithms", obj.TunnelDigestAlgorithms)
+	d.Set("dh_groups", obj.DhGroups)
+
+	err = setL3VpnSessionInSchema(d, obj.L3vpnSession)
+	if err != nil {
+		return handleReadError(d, "L3Vpn", id, err)
+	}
+
+	return nil
+}
+
+func resourceNsxtPolicyL3VpnUpdate(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	gwPath := d.Get("gateway_path").(string)
+	_, gwID := parseGatewayPolicyPath(gwPath)
+	localeServiceID := d.Get("locale_service_id").(string)
+	if id == "" || gwID == "" || localeServiceID == "" {
+		return fmt.Errorf("Error obtaining Gateway id or Locale Service id")
+	}
+
+	obj, err := policyL3VpnFromSchema(d)
+	if err != nil {
+		return handleUpdateError("L3Vpn", id, err)
+	}
+	revision := int64(d.Get("revision").(int))
+	obj.Revision = &revision
+
+	client := t0_locale_services.NewDefaultL3vpnsClient(connector)
+	err = client.Patch(gwID, localeServiceID, id, obj)
+	if err != nil {
+		return handleUpdateError("L3Vpn", id, err)
+	}
+
+	return resourceNsxtPolicyL3VpnRead(d, m)
+}
+
+func resourceNsxtPolicyL3VpnDelete(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	id := d.Id()
+	gwPath := d.Get("gateway_path").(string)
+	_, gwID := parseGatewayPolicyPath(gwPath)
+	localeServiceID := d.Get("locale_service_id").(string)
+	if id == "" || gwID == "" || localeServiceID == "" {
+		return fmt.Errorf("Error obtaining Gateway id or Locale Service id")
+	}
+
+	client := t0_locale_services.NewDefaultL3vpnsClient(connector)
+	err := client.Delete(gwID, localeServiceID, id)
+	if err != nil {
+		return handleDeleteError("L3Vpn", id, err)
+	}
+
+	return nil
+}
+
+func resourceNsxtPolicyL3VpnImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importPath := d.Id()
+	// L3Vpn path must be /infra/tier-0s/gw-id/locale-services/ls-id/l3vpns/l3vpn-id
+	segs := strings.Split(importPath, "/")
+	if len(segs) != 8 || segs[1] != "infra" || segs[2] != "tier-0s" || segs[4] != "locale-services" || segs[6] != "l3vpns" {
+		return nil, fmt.Errorf("Please provide L3Vpn policy path as an input, for example /infra/tier-0s/<gateway-id>/locale-services/<locale-service-id>/l3vpns/<l3vpn-id>")
+	}
+
+	d.Set("gateway_path", fmt.Sprintf("/infra/tier-0s/%s", segs[3]))
+	d.Set("locale_service_id", segs[5])
+	d.SetId(segs[7])
+
+	return []*schema.ResourceData{d}, nil
+}
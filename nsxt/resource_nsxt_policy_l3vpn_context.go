@@ -0,0 +1,346 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	t0_locale_services "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/locale_services"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+var l3VpnContextIkeLogLevelValues = []string{
+	model.L3VpnContext_IKE_LOG_LEVEL_DEBUG,
+	model.L3VpnContext_IKE_LOG_LEVEL_INFO,
+	model.L3VpnContext_IKE_LOG_LEVEL_WARN,
+	model.L3VpnContext_IKE_LOG_LEVEL_ERROR,
+	model.L3VpnContext_IKE_LOG_LEVEL_EMERGENCY,
+}
+
+// L3Vpn context is a singleton object per Tier-0 locale service
+const l3VpnContextID = "l3vpn-context"
+
+func resourceNsxtPolicyL3VpnContext() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNsxtPolicyL3VpnContextCreate,
+		Read:   resourceNsxtPolicyL3VpnContextRead,
+		Update: resourceNsxtPolicyL3VpnContextUpdate,
+		Delete: resourceNsxtPolicyL3VpnContextDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceNsxtPolicyL3VpnContextImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path":              getPathSchema(),
+			"display_name":      getDisplayNameSchema(),
+			"description":       getDescriptionSchema(),
+			"revision":          getRevisionSchema(),
+			"tag":               getTagsSchema(),
+			"gateway_path":      getPolicyPathSchema(true, true, "Policy path for Tier0 gateway"),
+			"locale_service_id": getComputedLocaleServiceIDSchema(),
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable/Disable L3Vpn service for the Tier0 gateway",
+				Optional:    true,
+				Default:     true,
+			},
+			"ike_log_level": {
+				Type:         schema.TypeString,
+				Description:  "Log level for internet key exchange (IKE)",
+				Optional:     true,
+				Default:      model.L3VpnContext_IKE_LOG_LEVEL_INFO,
+				ValidateFunc: validation.StringInSlice(l3VpnContextIkeLogLevelValues, false),
+			},
+			"label_path": getPolicyPathSchema(false, false, "Policy path of label used to group route based L3Vpns in order to apply edge firewall rules on their tunnel interfaces"),
+			"bypass_rule": {
+				Type:        schema.TypeList,
+				Description: "Bypass rules shared across all L3Vpns on the Tier0 gateway",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: getL3VpnRuleElemSchema(),
+				},
+			},
+			"available_local_address": {
+				Type:        schema.TypeList,
+				Description: "Local IPv4 addresses available for configuration of L3Vpns",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:         schema.TypeString,
+							Description:  "IPv4 address",
+							Required:     true,
+							ValidateFunc: validation.IsIPv4Address,
+						},
+						"display_name": {
+							Type:        schema.TypeString,
+							Description: "Display name used to help identify the address",
+							Optional:    true,
+						},
+						"next_hop": {
+							Type:         schema.TypeString,
+							Description:  "Next hop used in auto-plumbing of static route. If not specified, static route is not auto-plumbed",
+							Optional:     true,
+							ValidateFunc: validation.IsIPv4Address,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getNsxtPolicyL3VpnContext(connector *client.RestConnector, gwID string, localeServiceID string) (model.L3VpnContext, error) {
+	client := t0_locale_services.NewDefaultL3vpnContextClient(connector)
+	return client.Get(gwID, localeServiceID)
+}
+
+// There is no direct PATCH API for L3Vpn context, hence hierarchical API is used
+func patchNsxtPolicyL3VpnContext(connector *client.RestConnector, gwID string, localeServiceID string, obj model.L3VpnContext) error {
+	converter := bindings.NewTypeConverter()
+	converter.SetMode(bindings.REST)
+
+	id := l3VpnContextID
+	contextType := "L3VpnContext"
+	obj.Id = &id
+	obj.ResourceType = &contextType
+	childContext := model.ChildL3VpnContext{
+		ResourceType: "ChildL3VpnContext",
+		L3VpnContext: &obj,
+	}
+	dataValue, errors := converter.ConvertToVapi(childContext, model.ChildL3VpnContextBindingType())
+	if errors != nil {
+		return fmt.Errorf("Error converting L3Vpn Context Child: %v", errors[0])
+	}
+
+	lsType := "LocaleServices"
+	serviceStruct := model.LocaleServices{
+		Id:           &localeServiceID,
+		ResourceType: &lsType,
+		Children:     []*data.StructValue{dataValue.(*data.StructValue)},
+	}
+	childService, err := initChildLocaleService(&serviceStruct, false)
+	if err != nil {
+		return err
+	}
+
+	t0Type := "Tier0"
+	t0Struct := model.Tier0{
+		Id:           &gwID,
+		ResourceType: &t0Type,
+		Children:     []*data.StructValue{childService},
+	}
+	childTier0 := model.ChildTier0{
+		Tier0:        &t0Struct,
+		ResourceType: "ChildTier0",
+	}
+	dataValue, errors = converter.ConvertToVapi(childTier0, model.ChildTier0BindingType())
+	if errors != nil {
+		return fmt.Errorf("Error converting Tier0 Child: %v", errors[0])
+	}
+
+	infraType := "Infra"
+	infraStruct := model.Infra{
+		Children:     []*data.StructValue{dataValue.(*data.StructValue)},
+		ResourceType: &infraType,
+	}
+
+	return policyInfraPatch(infraStruct, false, connector, false)
+}
+
+func getL3VpnContextAvailableLocalAddressesFromSchema(d *schema.ResourceData) []model.PolicyIPAddressInfo {
+	addresses := []model.PolicyIPAddressInfo{}
+	for _, address := range d.Get("available_local_address").([]interface{}) {
+		data := address.(map[string]interface{})
+		addressValue := data["address"].(string)
+		elem := model.PolicyIPAddressInfo{
+			AddressValue: &addressValue,
+		}
+		displayName := data["display_name"].(string)
+		if displayName != "" {
+			elem.DisplayName = &displayName
+		}
+		nextHop := data["next_hop"].(string)
+		if nextHop != "" {
+			elem.NextHop = &nextHop
+		}
+		addresses = append(addresses, elem)
+	}
+	return addresses
+}
+
+func setL3VpnContextAvailableLocalAddressesInSchema(d *schema.ResourceData, addresses []model.PolicyIPAddressInfo) error {
+	var addressList []map[string]interface{}
+	for _, address := range addresses {
+		elem := make(map[string]interface{})
+		elem["address"] = address.AddressValue
+		elem["display_name"] = address.DisplayName
+		elem["next_hop"] = address.NextHop
+		addressList = append(addressList, elem)
+	}
+	return d.Set("available_local_address", addressList)
+}
+
+func policyL3VpnContextFromSchema(d *schema.ResourceData) model.L3VpnContext {
+	displayName := d.Get("display_name").(string)
+	description := d.Get("description").(string)
+	tags := getPolicyTagsFromSchema(d)
+	enabled := d.Get("enabled").(bool)
+	ikeLogLevel := d.Get("ike_log_level").(string)
+
+	obj := model.L3VpnContext{
+		DisplayName:             &displayName,
+		Description:             &description,
+		Tags:                    tags,
+		Enabled:                 &enabled,
+		IkeLogLevel:             &ikeLogLevel,
+		BypassRules:             getL3VpnRulesFromList(d.Get("bypass_rule").([]interface{}), model.L3VpnRule_ACTION_BYPASS),
+		AvailableLocalAddresses: getL3VpnContextAvailableLocalAddressesFromSchema(d),
+	}
+	if obj.BypassRules == nil {
+		obj.BypassRules = []model.L3VpnRule{}
+	}
+
+	labelPath := d.Get("label_path").(string)
+	if labelPath != "" {
+		obj.Label = &labelPath
+	}
+
+	return obj
+}
+
+func resourceNsxtPolicyL3VpnContextCreate(d *schema.ResourceData, m interface{}) error {
+	if isPolicyGlobalManager(m) {
+		return localManagerOnlyError()
+	}
+
+	connector := getPolicyConnector(m)
+
+	gwPath := d.Get("gateway_path").(string)
+	isT0, gwID := parseGatewayPolicyPath(gwPath)
+	if gwID == "" {
+		return fmt.Errorf("gateway_path is not valid")
+	}
+	if !isT0 {
+		return fmt.Errorf("Tier0 gateway path expected, got %s", gwPath)
+	}
+
+	localeServiceID, err := getPolicyGatewayLocaleServiceID(connector, isT0, gwID)
+	if err != nil {
+		return err
+	}
+
+	obj := policyL3VpnContextFromSchema(d)
+
+	log.Printf("[INFO] Creating L3Vpn Context for Gateway %s", gwID)
+	err = patchNsxtPolicyL3VpnContext(connector, gwID, localeServiceID, obj)
+	if err != nil {
+		return handleCreateError("L3Vpn Context", gwID, err)
+	}
+
+	d.SetId(gwID)
+	d.Set("locale_service_id", localeServiceID)
+
+	return resourceNsxtPolicyL3VpnContextRead(d, m)
+}
+
+func resourceNsxtPolicyL3VpnContextRead(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	gwPath := d.Get("gateway_path").(string)
+	_, gwID := parseGatewayPolicyPath(gwPath)
+	localeServiceID := d.Get("locale_service_id").(string)
+	if gwID == "" || localeServiceID == "" {
+		return fmt.Errorf("Error obtaining Gateway id or Locale Service id")
+	}
+
+	obj, err := getNsxtPolicyL3VpnContext(connector, gwID, localeServiceID)
+	if err != nil {
+		return handleReadError(d, "L3Vpn Context", gwID, err)
+	}
+
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	setPolicyTagsInSchema(d, obj.Tags)
+	d.Set("path", obj.Path)
+	d.Set("revision", obj.Revision)
+	d.Set("enabled", obj.Enabled)
+	d.Set("ike_log_level", obj.IkeLogLevel)
+	d.Set("label_path", obj.Label)
+
+	err = d.Set("bypass_rule", getL3VpnRulesList(obj.BypassRules))
+	if err != nil {
+		return handleReadError(d, "L3Vpn Context", gwID, err)
+	}
+
+	return setL3VpnContextAvailableLocalAddressesInSchema(d, obj.AvailableLocalAddresses)
+}
+
+func resourceNsxtPolicyL3VpnContextUpdate(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	gwPath := d.Get("gateway_path").(string)
+	_, gwID := parseGatewayPolicyPath(gwPath)
+	localeServiceID := d.Get("locale_service_id").(string)
+	if gwID == "" || localeServiceID == "" {
+		return fmt.Errorf("Error obtaining Gateway id or Locale Service id")
+	}
+
+	obj := policyL3VpnContextFromSchema(d)
+
+	log.Printf("[INFO] Updating L3Vpn Context for Gateway %s", gwID)
+	err := patchNsxtPolicyL3VpnContext(connector, gwID, localeServiceID, obj)
+	if err != nil {
+		return handleUpdateError("L3Vpn Context", gwID, err)
+	}
+
+	return resourceNsxtPolicyL3VpnContextRead(d, m)
+}
+
+func resourceNsxtPolicyL3VpnContextDelete(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	gwPath := d.Get("gateway_path").(string)
+	_, gwID := parseGatewayPolicyPath(gwPath)
+	localeServiceID := d.Get("locale_service_id").(string)
+	if gwID == "" || localeServiceID == "" {
+		return fmt.Errorf("Error obtaining Gateway id or Locale Service id")
+	}
+
+	// There is no DELETE API for this object - we need to disable it and clear its configuration
+	enabled := false
+	obj := model.L3VpnContext{
+		Enabled:                 &enabled,
+		BypassRules:             []model.L3VpnRule{},
+		AvailableLocalAddresses: []model.PolicyIPAddressInfo{},
+	}
+	err := patchNsxtPolicyL3VpnContext(connector, gwID, localeServiceID, obj)
+	if err != nil {
+		return handleDeleteError("L3Vpn Context", gwID, err)
+	}
+
+	return nil
+}
+
+func resourceNsxtPolicyL3VpnContextImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importPath := d.Id()
+	// context path must be /infra/tier-0s/gw-id/locale-services/ls-id/l3vpn-context
+	segs := strings.Split(importPath, "/")
+	if len(segs) != 7 || segs[1] != "infra" || segs[2] != "tier-0s" || segs[4] != "locale-services" || segs[6] != l3VpnContextID {
+		return nil, fmt.Errorf("Please provide L3Vpn Context policy path as an input, for example /infra/tier-0s/<gateway-id>/locale-services/<locale-service-id>/l3vpn-context")
+	}
+
+	d.Set("gateway_path", fmt.Sprintf("/infra/tier-0s/%s", segs[3]))
+	d.Set("locale_service_id", segs[5])
+	d.SetId(segs[3])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,197 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var accTestPolicyL3VpnContextCreateAttributes = map[string]string{
+	"display_name":  getAccTestResourceName(),
+	"description":   "terraform created",
+	"enabled":       "true",
+	"ike_log_level": "INFO",
+	"address":       "20.20.0.10",
+}
+
+var accTestPolicyL3VpnContextUpdateAttributes = map[string]string{
+	"display_name":  getAccTestResourceName(),
+	"description":   "terraform updated",
+	"enabled":       "false",
+	"ike_log_level": "DEBUG",
+	"address":       "20.20.0.11",
+}
+
+func TestAccResourceNsxtPolicyL3VpnContext_basic(t *testing.T) {
+	testResourceName := "nsxt_policy_l3vpn_context.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyL3VpnContextCheckDestroy(state)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyL3VpnContextTemplate(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyL3VpnContextExists(testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyL3VpnContextCreateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyL3VpnContextCreateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "enabled", accTestPolicyL3VpnContextCreateAttributes["enabled"]),
+					resource.TestCheckResourceAttr(testResourceName, "ike_log_level", accTestPolicyL3VpnContextCreateAttributes["ike_log_level"]),
+					resource.TestCheckResourceAttr(testResourceName, "available_local_address.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "available_local_address.0.address", accTestPolicyL3VpnContextCreateAttributes["address"]),
+					resource.TestCheckResourceAttr(testResourceName, "bypass_rule.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "bypass_rule.0.sources.#", "1"),
+					resource.TestCheckResourceAttrSet(testResourceName, "bypass_rule.0.nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "gateway_path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "locale_service_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyL3VpnContextTemplate(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyL3VpnContextExists(testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyL3VpnContextUpdateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyL3VpnContextUpdateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "enabled", accTestPolicyL3VpnContextUpdateAttributes["enabled"]),
+					resource.TestCheckResourceAttr(testResourceName, "ike_log_level", accTestPolicyL3VpnContextUpdateAttributes["ike_log_level"]),
+					resource.TestCheckResourceAttr(testResourceName, "available_local_address.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "available_local_address.0.address", accTestPolicyL3VpnContextUpdateAttributes["address"]),
+					resource.TestCheckResourceAttr(testResourceName, "bypass_rule.#", "1"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyL3VpnContextMinimalistic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyL3VpnContextExists(testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "description", ""),
+					resource.TestCheckResourceAttr(testResourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(testResourceName, "bypass_rule.#", "0"),
+					resource.TestCheckResourceAttr(testResourceName, "available_local_address.#", "0"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyL3VpnContext_importBasic(t *testing.T) {
+	testResourceName := "nsxt_policy_l3vpn_context.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyL3VpnContextCheckDestroy(state)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyL3VpnContextTemplate(true),
+			},
+			{
+				ResourceName:      testResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccNsxtPolicyVpnPathImporterGetID(testResourceName),
+			},
+		},
+	})
+}
+
+func testAccNsxtPolicyL3VpnContextExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+
+		connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Policy L3Vpn Context resource %s not found in resources", resourceName)
+		}
+
+		_, gwID := parseGatewayPolicyPath(rs.Primary.Attributes["gateway_path"])
+		_, err := getNsxtPolicyL3VpnContext(connector, gwID, rs.Primary.Attributes["locale_service_id"])
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccNsxtPolicyL3VpnContextCheckDestroy(state *terraform.State) error {
+	connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+	for _, rs := range state.RootModule().Resources {
+
+		if rs.Type != "nsxt_policy_l3vpn_context" {
+			continue
+		}
+
+		_, gwID := parseGatewayPolicyPath(rs.Primary.Attributes["gateway_path"])
+		obj, err := getNsxtPolicyL3VpnContext(connector, gwID, rs.Primary.Attributes["locale_service_id"])
+		if err != nil {
+			if isNotFoundError(err) {
+				continue
+			}
+			return err
+		}
+
+		// There is no DELETE API for L3Vpn context, it is disabled on destroy
+		if obj.Enabled != nil && *obj.Enabled {
+			return fmt.Errorf("Policy L3Vpn Context on Gateway %s is still enabled", gwID)
+		}
+	}
+	return nil
+}
+
+func testAccNsxtPolicyL3VpnContextTemplate(createFlow bool) string {
+	var attrMap map[string]string
+	if createFlow {
+		attrMap = accTestPolicyL3VpnContextCreateAttributes
+	} else {
+		attrMap = accTestPolicyL3VpnContextUpdateAttributes
+	}
+	return testAccNsxtPolicyEdgeClusterReadTemplate(getEdgeClusterName()) + testAccNsxtPolicyTier0WithEdgeClusterTemplate("test", true) + fmt.Sprintf(`
+resource "nsxt_policy_l3vpn_context" "test" {
+  display_name  = "%s"
+  description   = "%s"
+  gateway_path  = nsxt_policy_tier0_gateway.test.path
+  enabled       = %s
+  ike_log_level = "%s"
+
+  available_local_address {
+    address      = "%s"
+    display_name = "local"
+  }
+
+  bypass_rule {
+    sources      = ["192.168.10.0/24"]
+    destinations = ["192.169.10.0/24"]
+  }
+
+  tag {
+    scope = "scope1"
+    tag   = "tag1"
+  }
+}`, attrMap["display_name"], attrMap["description"], attrMap["enabled"], attrMap["ike_log_level"], attrMap["address"])
+}
+
+func testAccNsxtPolicyL3VpnContextMinimalistic() string {
+	return testAccNsxtPolicyEdgeClusterReadTemplate(getEdgeClusterName()) + testAccNsxtPolicyTier0WithEdgeClusterTemplate("test", true) + fmt.Sprintf(`
+resource "nsxt_policy_l3vpn_context" "test" {
+  display_name = "%s"
+  gateway_path = nsxt_policy_tier0_gateway.test.path
+}`, accTestPolicyL3VpnContextUpdateAttributes["display_name"])
+}
@@ -0,0 +1,254 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var accTestPolicyL3VpnHelperName = getAccTestResourceName()
+
+var accTestPolicyL3VpnCreateAttributes = map[string]string{
+	"display_name":          getAccTestResourceName(),
+	"description":           "terraform created",
+	"enabled":               "true",
+	"remote_public_address": "18.18.18.19",
+	"ike_version":           "IKE_V2",
+	"pfs":                   "true",
+}
+
+var accTestPolicyL3VpnUpdateAttributes = map[string]string{
+	"display_name":          getAccTestResourceName(),
+	"description":           "terraform updated",
+	"enabled":               "false",
+	"remote_public_address": "18.18.18.20",
+	"ike_version":           "IKE_FLEX",
+	"pfs":                   "false",
+}
+
+func TestAccResourceNsxtPolicyL3Vpn_policyBased(t *testing.T) {
+	testResourceName := "nsxt_policy_l3vpn.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyL3VpnCheckDestroy(state, accTestPolicyL3VpnUpdateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyL3VpnPolicyBasedTemplate(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyL3VpnExists(accTestPolicyL3VpnCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyL3VpnCreateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyL3VpnCreateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "enabled", accTestPolicyL3VpnCreateAttributes["enabled"]),
+					resource.TestCheckResourceAttr(testResourceName, "local_address", "20.20.0.10"),
+					resource.TestCheckResourceAttr(testResourceName, "remote_public_address", accTestPolicyL3VpnCreateAttributes["remote_public_address"]),
+					resource.TestCheckResourceAttrSet(testResourceName, "remote_private_address"),
+					resource.TestCheckResourceAttr(testResourceName, "ike_version", accTestPolicyL3VpnCreateAttributes["ike_version"]),
+					resource.TestCheckResourceAttr(testResourceName, "enable_perfect_forward_secrecy", accTestPolicyL3VpnCreateAttributes["pfs"]),
+					resource.TestCheckResourceAttr(testResourceName, "session_type", "PolicyBasedL3VpnSession"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.sources.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.destinations.#", "1"),
+					resource.TestCheckResourceAttrSet(testResourceName, "rule.0.nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "locale_service_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyL3VpnPolicyBasedTemplate(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyL3VpnExists(accTestPolicyL3VpnUpdateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", accTestPolicyL3VpnUpdateAttributes["display_name"]),
+					resource.TestCheckResourceAttr(testResourceName, "description", accTestPolicyL3VpnUpdateAttributes["description"]),
+					resource.TestCheckResourceAttr(testResourceName, "enabled", accTestPolicyL3VpnUpdateAttributes["enabled"]),
+					resource.TestCheckResourceAttr(testResourceName, "remote_public_address", accTestPolicyL3VpnUpdateAttributes["remote_public_address"]),
+					resource.TestCheckResourceAttr(testResourceName, "ike_version", accTestPolicyL3VpnUpdateAttributes["ike_version"]),
+					resource.TestCheckResourceAttr(testResourceName, "enable_perfect_forward_secrecy", accTestPolicyL3VpnUpdateAttributes["pfs"]),
+					resource.TestCheckResourceAttr(testResourceName, "session_type", "PolicyBasedL3VpnSession"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "1"),
+					resource.TestCheckResourceAttrSet(testResourceName, "nsx_id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyL3Vpn_routeBased(t *testing.T) {
+	testResourceName := "nsxt_policy_l3vpn.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyL3VpnCheckDestroy(state, accTestPolicyL3VpnCreateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyL3VpnRouteBasedTemplate(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyL3VpnExists(accTestPolicyL3VpnCreateAttributes["display_name"], testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "session_type", "RouteBasedL3VpnSession"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_subnet.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_subnet.0.ip_addresses.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "tunnel_subnet.0.prefix_length", "30"),
+					resource.TestCheckResourceAttr(testResourceName, "force_whitelisting", "true"),
+					resource.TestCheckResourceAttr(testResourceName, "default_rule_logging", "true"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "0"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyL3Vpn_importBasic(t *testing.T) {
+	testResourceName := "nsxt_policy_l3vpn.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyL3VpnCheckDestroy(state, accTestPolicyL3VpnCreateAttributes["display_name"])
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyL3VpnPolicyBasedTemplate(true),
+			},
+			{
+				ResourceName:            testResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccNsxtPolicyVpnPathImporterGetID(testResourceName),
+				ImportStateVerifyIgnore: []string{"passphrases"},
+			},
+		},
+	})
+}
+
+func testAccNsxtPolicyL3VpnExists(displayName string, resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+
+		connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Policy L3Vpn resource %s not found in resources", resourceName)
+		}
+
+		resourceID := rs.Primary.ID
+		if resourceID == "" {
+			return fmt.Errorf("Policy L3Vpn resource ID not set in resources")
+		}
+
+		_, gwID := parseGatewayPolicyPath(rs.Primary.Attributes["gateway_path"])
+		exists, err := resourceNsxtPolicyL3VpnExists(connector, gwID, rs.Primary.Attributes["locale_service_id"], resourceID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("Policy L3Vpn %s does not exist", resourceID)
+		}
+
+		return nil
+	}
+}
+
+func testAccNsxtPolicyL3VpnCheckDestroy(state *terraform.State, displayName string) error {
+	connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+	for _, rs := range state.RootModule().Resources {
+
+		if rs.Type != "nsxt_policy_l3vpn" {
+			continue
+		}
+
+		resourceID := rs.Primary.Attributes["id"]
+		_, gwID := parseGatewayPolicyPath(rs.Primary.Attributes["gateway_path"])
+		exists, err := resourceNsxtPolicyL3VpnExists(connector, gwID, rs.Primary.Attributes["locale_service_id"], resourceID)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return fmt.Errorf("Policy L3Vpn %s still exists", displayName)
+		}
+	}
+	return nil
+}
+
+func testAccNsxtPolicyL3VpnPrerequisites() string {
+	return testAccNsxtPolicyEdgeClusterReadTemplate(getEdgeClusterName()) + testAccNsxtPolicyTier0WithEdgeClusterTemplate("test", true) + fmt.Sprintf(`
+resource "nsxt_policy_l3vpn_context" "test" {
+  display_name = "%s"
+  gateway_path = nsxt_policy_tier0_gateway.test.path
+
+  available_local_address {
+    address = "20.20.0.10"
+  }
+}`, accTestPolicyL3VpnHelperName)
+}
+
+func testAccNsxtPolicyL3VpnPolicyBasedTemplate(createFlow bool) string {
+	var attrMap map[string]string
+	if createFlow {
+		attrMap = accTestPolicyL3VpnCreateAttributes
+	} else {
+		attrMap = accTestPolicyL3VpnUpdateAttributes
+	}
+	return testAccNsxtPolicyL3VpnPrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_l3vpn" "test" {
+  display_name                   = "%s"
+  description                    = "%s"
+  gateway_path                   = nsxt_policy_l3vpn_context.test.gateway_path
+  enabled                        = %s
+  local_address                  = nsxt_policy_l3vpn_context.test.available_local_address[0].address
+  remote_public_address          = "%s"
+  passphrases                    = ["secret1"]
+  ike_version                    = "%s"
+  enable_perfect_forward_secrecy = %s
+  session_type                   = "PolicyBasedL3VpnSession"
+
+  rule {
+    sources      = ["192.168.10.0/24"]
+    destinations = ["192.169.10.0/24"]
+  }
+
+  tag {
+    scope = "scope1"
+    tag   = "tag1"
+  }
+}`, attrMap["display_name"], attrMap["description"], attrMap["enabled"], attrMap["remote_public_address"], attrMap["ike_version"], attrMap["pfs"])
+}
+
+func testAccNsxtPolicyL3VpnRouteBasedTemplate() string {
+	return testAccNsxtPolicyL3VpnPrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_l3vpn" "test" {
+  display_name          = "%s"
+  gateway_path          = nsxt_policy_l3vpn_context.test.gateway_path
+  local_address         = nsxt_policy_l3vpn_context.test.available_local_address[0].address
+  remote_public_address = "%s"
+  passphrases           = ["secret1"]
+  session_type          = "RouteBasedL3VpnSession"
+  force_whitelisting    = true
+  default_rule_logging  = true
+
+  tunnel_subnet {
+    ip_addresses  = ["169.254.152.2"]
+    prefix_length = 30
+  }
+}`, accTestPolicyL3VpnCreateAttributes["display_name"], accTestPolicyL3VpnCreateAttributes["remote_public_address"])
+}
@@ -17,7 +17,8 @@ func resourceNsxtPolicySegment() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
-		Schema: getPolicyCommonSegmentSchema(false, false),
+		Schema:        getPolicyCommonSegmentSchema(false, false),
+		CustomizeDiff: nsxtPolicySegmentL2ExtensionValidate,
 	}
 }
 
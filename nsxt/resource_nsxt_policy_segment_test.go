@@ -2,6 +2,7 @@ package nsxt
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -357,7 +358,110 @@ func TestAccResourceNsxtPolicySegment_withDhcp(t *testing.T) {
 	})
 }
 
-// TODO: add tests for l2_extension; requires L2 VPN Session
+func TestAccResourceNsxtPolicySegment_l2ExtensionInvalidPath(t *testing.T) {
+	name := getAccTestResourceName()
+	tzName := getOverlayTransportZoneName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXPolicyTransportZoneReadTemplate(tzName, false, true) + testAccNsxtPolicyL2VpnSessionPrerequisites(),
+			},
+			{
+				// IPSec VPN session exists at plan time, and is rejected by its resource type
+				Config:      testAccNSXPolicyTransportZoneReadTemplate(tzName, false, true) + testAccNsxtPolicyL2VpnSessionPrerequisites() + testAccNsxtPolicySegmentL2ExtensionSegmentTemplate("test", name, "nsxt_policy_ipsec_vpn_session.test.path"),
+				ExpectError: regexp.MustCompile(`is not an L2VPN session`),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicySegment_l2ExtensionDuplicateTunnelID(t *testing.T) {
+	name := getAccTestResourceName()
+	tzName := getOverlayTransportZoneName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicySegmentCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicySegmentL2ExtensionDuplicateTunnelIDTemplate(tzName, name, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicySegmentExists("nsxt_policy_segment.test"),
+				),
+			},
+			{
+				Config:      testAccNsxtPolicySegmentL2ExtensionDuplicateTunnelIDTemplate(tzName, name, true),
+				ExpectError: regexp.MustCompile(`Tunnel ID 100 is already used by segment`),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicySegment_l2ExtensionInvalidCombination(t *testing.T) {
+	name := getAccTestResourceName()
+	tzName := getOverlayTransportZoneName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicySegmentL2ExtensionBlockTemplate(tzName, name, `
+    l2vpn_paths = ["/infra/tier-0s/gw1/locale-services/default/l2vpn-services/default/sessions/s1"]`),
+				ExpectError: regexp.MustCompile(`tunnel_id is required in l2_extension`),
+			},
+			{
+				Config: testAccNsxtPolicySegmentL2ExtensionBlockTemplate(tzName, name, `
+    tunnel_id                = 100
+    local_egress_gateway_ips = ["10.10.10.1"]`),
+				ExpectError: regexp.MustCompile(`local_egress_gateway_ips in l2_extension require l2vpn_paths`),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicySegment_l2ExtensionLocalEgress(t *testing.T) {
+	name := getAccTestResourceName()
+	tzName := getOverlayTransportZoneName()
+	testResourceName := "nsxt_policy_segment.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicySegmentCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicySegmentL2ExtensionLocalEgressTemplate(tzName, name, "10.10.10.1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicySegmentExists(testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "l2_extension.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "l2_extension.0.l2vpn_paths.#", "1"),
+					resource.TestCheckResourceAttrPair(testResourceName, "l2_extension.0.l2vpn_paths.0", "nsxt_policy_l2vpn_session.test", "path"),
+					resource.TestCheckResourceAttr(testResourceName, "l2_extension.0.tunnel_id", "100"),
+					resource.TestCheckResourceAttr(testResourceName, "l2_extension.0.local_egress_gateway_ips.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "l2_extension.0.local_egress_gateway_ips.0", "10.10.10.1"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicySegmentL2ExtensionLocalEgressTemplate(tzName, name, "10.10.10.2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicySegmentExists(testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "l2_extension.0.tunnel_id", "100"),
+					resource.TestCheckResourceAttr(testResourceName, "l2_extension.0.local_egress_gateway_ips.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "l2_extension.0.local_egress_gateway_ips.0", "10.10.10.2"),
+				),
+			},
+		},
+	})
+}
 
 func testAccNsxtPolicySegmentExists(resourceName string) resource.TestCheckFunc {
 	return testAccNsxtPolicyResourceExists(resourceName, resourceNsxtPolicySegmentExists("", false))
@@ -410,6 +514,67 @@ resource "nsxt_policy_segment" "test" {
 `, name)
 }
 
+func testAccNsxtPolicySegmentL2ExtensionSegmentTemplate(resourceName string, name string, sessionPath string) string {
+	return fmt.Sprintf(`
+resource "nsxt_policy_segment" "%s" {
+  display_name        = "%s"
+  transport_zone_path = data.nsxt_policy_transport_zone.test.path
+
+  l2_extension {
+    l2vpn_paths = [%s]
+    tunnel_id   = 100
+  }
+}
+`, resourceName, name, sessionPath)
+}
+
+func testAccNsxtPolicySegmentL2ExtensionDuplicateTunnelIDTemplate(tzName string, name string, withDuplicate bool) string {
+	config := testAccNSXPolicyTransportZoneReadTemplate(tzName, false, true) + testAccNsxtPolicyL2VpnSessionPrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_l2vpn_session" "test" {
+  display_name      = "%s"
+  service_path      = nsxt_policy_l2vpn_service.test.path
+  transport_tunnels = [nsxt_policy_ipsec_vpn_session.test.path]
+}
+`, name) + testAccNsxtPolicySegmentL2ExtensionSegmentTemplate("test", name, "nsxt_policy_l2vpn_session.test.path")
+	if withDuplicate {
+		config += testAccNsxtPolicySegmentL2ExtensionSegmentTemplate("duplicate", name, "nsxt_policy_l2vpn_session.test.path")
+	}
+	return config
+}
+
+func testAccNsxtPolicySegmentL2ExtensionBlockTemplate(tzName string, name string, l2Extension string) string {
+	return testAccNSXPolicyTransportZoneReadTemplate(tzName, false, true) + fmt.Sprintf(`
+resource "nsxt_policy_segment" "test" {
+  display_name        = "%s"
+  transport_zone_path = data.nsxt_policy_transport_zone.test.path
+
+  l2_extension {%s
+  }
+}
+`, name, l2Extension)
+}
+
+func testAccNsxtPolicySegmentL2ExtensionLocalEgressTemplate(tzName string, name string, egressIP string) string {
+	return testAccNSXPolicyTransportZoneReadTemplate(tzName, false, true) + testAccNsxtPolicyL2VpnSessionPrerequisites() + fmt.Sprintf(`
+resource "nsxt_policy_l2vpn_session" "test" {
+  display_name      = "%s"
+  service_path      = nsxt_policy_l2vpn_service.test.path
+  transport_tunnels = [nsxt_policy_ipsec_vpn_session.test.path]
+}
+
+resource "nsxt_policy_segment" "test" {
+  display_name        = "%s"
+  transport_zone_path = data.nsxt_policy_transport_zone.test.path
+
+  l2_extension {
+    l2vpn_paths              = [nsxt_policy_l2vpn_session.test.path]
+    tunnel_id                = 100
+    local_egress_gateway_ips = ["%s"]
+  }
+}
+`, name, name, egressIP)
+}
+
 func testAccNsxtPolicySegmentBasicTemplate(tzName string, name string) string {
 	return testAccNsxtPolicySegmentDeps(tzName) + fmt.Sprintf(`
 
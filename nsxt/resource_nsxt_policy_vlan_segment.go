@@ -21,7 +21,8 @@ func resourceNsxtPolicyVlanSegment() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
-		Schema: segSchema,
+		Schema:        segSchema,
+		CustomizeDiff: nsxtPolicySegmentL2ExtensionValidate,
 	}
 }
 
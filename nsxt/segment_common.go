@@ -1,6 +1,7 @@
 package nsxt
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -142,10 +143,19 @@ func getPolicySegmentL2ExtensionConfigurationSchema() *schema.Resource {
 			},
 			"tunnel_id": {
 				Type:         schema.TypeInt,
-				Description:  "Tunnel ID",
+				Description:  "Tunnel ID, unique per L2 VPN session. Required when l2vpn_paths are specified",
 				Optional:     true,
 				ValidateFunc: validation.IntBetween(1, 4093),
 			},
+			"local_egress_gateway_ips": {
+				Type:        schema.TypeList,
+				Description: "Gateway IPs for local egress. Local egress is enabled only when this list is not empty",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPAddress,
+				},
+				Optional: true,
+			},
 		},
 	}
 }
@@ -754,7 +764,13 @@ func policySegmentResourceToInfraStruct(id string, d *schema.ResourceData, isVla
 		tunnelID := int64(l2ExtMap["tunnel_id"].(int))
 		l2Struct := model.L2Extension{
 			L2vpnPaths: vpnPaths,
-			TunnelId:   &tunnelID,
+		}
+		if tunnelID > 0 {
+			l2Struct.TunnelId = &tunnelID
+		}
+		egressIPs := interfaceListToStringList(l2ExtMap["local_egress_gateway_ips"].([]interface{}))
+		if len(egressIPs) > 0 {
+			l2Struct.LocalEgress = &model.LocalEgress{OptimizedIps: egressIPs}
 		}
 		obj.L2Extension = &l2Struct
 	}
@@ -1301,6 +1317,9 @@ func nsxtPolicySegmentRead(d *schema.ResourceData, m interface{}, isVlan bool, i
 		l2Ext := make(map[string]interface{})
 		l2Ext["l2vpn_paths"] = obj.L2Extension.L2vpnPaths
 		l2Ext["tunnel_id"] = obj.L2Extension.TunnelId
+		if obj.L2Extension.LocalEgress != nil {
+			l2Ext["local_egress_gateway_ips"] = obj.L2Extension.LocalEgress.OptimizedIps
+		}
 		// This is a list with 1 element
 		var l2ExtList []map[string]interface{}
 		l2ExtList = append(l2ExtList, l2Ext)
@@ -1332,6 +1351,117 @@ func nsxtPolicySegmentRead(d *schema.ResourceData, m interface{}, isVlan bool, i
 	return nil
 }
 
+func nsxtPolicySegmentValidateL2VpnSessionPath(connector *client.RestConnector, path string) error {
+	segs := strings.Split(path, "/")
+	sessionID := segs[len(segs)-1]
+	results, err := listPolicyResourcesByID(connector, false, &sessionID, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to verify L2VPN session %s: %v", path, err)
+	}
+
+	converter := bindings.NewTypeConverter()
+	converter.SetMode(bindings.REST)
+	for _, result := range results {
+		dataValue, errors := converter.ConvertToGolang(result, model.PolicyResourceBindingType())
+		if len(errors) > 0 {
+			return errors[0]
+		}
+		policyResource := dataValue.(model.PolicyResource)
+		if policyResource.Path == nil || *policyResource.Path != path {
+			continue
+		}
+		if policyResource.ResourceType == nil || *policyResource.ResourceType != "L2VPNSession" {
+			return fmt.Errorf("%s in l2vpn_paths is not an L2VPN session", path)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("L2VPN session %s in l2vpn_paths was not found", path)
+}
+
+func nsxtPolicySegmentL2ExtensionValidate(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("l2_extension") {
+		return nil
+	}
+
+	l2Ext := d.Get("l2_extension").([]interface{})
+	if len(l2Ext) == 0 || l2Ext[0] == nil {
+		return nil
+	}
+
+	l2ExtMap := l2Ext[0].(map[string]interface{})
+	tunnelID := int64(l2ExtMap["tunnel_id"].(int))
+	pathList := l2ExtMap["l2vpn_paths"].([]interface{})
+	pathsKnown := d.NewValueKnown("l2_extension.0.l2vpn_paths")
+	egressIPs := l2ExtMap["local_egress_gateway_ips"].([]interface{})
+	if pathsKnown && len(pathList) == 0 {
+		if len(egressIPs) > 0 {
+			return fmt.Errorf("local_egress_gateway_ips in l2_extension require l2vpn_paths to be specified")
+		}
+		return nil
+	}
+
+	if tunnelID == 0 && d.NewValueKnown("l2_extension.0.tunnel_id") {
+		return fmt.Errorf("tunnel_id is required in l2_extension when l2vpn_paths are specified")
+	}
+
+	vpnPaths := make(map[string]bool)
+	for _, path := range interfaceListToStringList(pathList) {
+		if path == "" {
+			// value not known yet
+			continue
+		}
+		if vpnPaths[path] {
+			return fmt.Errorf("L2VPN session %s is specified more than once in l2_extension", path)
+		}
+		vpnPaths[path] = true
+	}
+
+	if tunnelID == 0 || len(vpnPaths) == 0 || isPolicyGlobalManager(m) {
+		return nil
+	}
+
+	connector := getPolicyConnector(m)
+	for path := range vpnPaths {
+		err := nsxtPolicySegmentValidateL2VpnSessionPath(connector, path)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Verify no other segment uses the same tunnel ID on any of the sessions
+	resourceType := "Segment"
+	query := fmt.Sprintf("l2_extension.tunnel_id:%d", tunnelID)
+	results, err := listPolicyResourcesByType(connector, false, &resourceType, &query)
+	if err != nil {
+		return fmt.Errorf("Failed to verify uniqueness of L2 extension tunnel ID %d: %v", tunnelID, err)
+	}
+
+	converter := bindings.NewTypeConverter()
+	converter.SetMode(bindings.REST)
+	segmentPath := d.Get("path").(string)
+	for _, result := range results {
+		dataValue, errors := converter.ConvertToGolang(result, model.SegmentBindingType())
+		if len(errors) > 0 {
+			return errors[0]
+		}
+		segment := dataValue.(model.Segment)
+		if segment.Path == nil || *segment.Path == segmentPath {
+			continue
+		}
+		if segment.L2Extension == nil || segment.L2Extension.TunnelId == nil || *segment.L2Extension.TunnelId != tunnelID {
+			continue
+		}
+		for _, path := range segment.L2Extension.L2vpnPaths {
+			if vpnPaths[path] {
+				return fmt.Errorf("Tunnel ID %d is already used by segment %s on L2VPN session %s", tunnelID, *segment.Path, path)
+			}
+		}
+	}
+
+	return nil
+}
+
 func nsxtPolicySegmentCreate(d *schema.ResourceData, m interface{}, isVlan bool, isFixed bool) error {
 
 	// Initialize resource Id and verify this ID is not yet used
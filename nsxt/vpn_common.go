@@ -0,0 +1,494 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func parseVpnServicePolicyPath(path string, serviceType string) (bool, string, string, string) {
+	// service path must be /infra/tier-Xs/gw-id/locale-services/ls-id/<service-type>/service-id
+	segs := strings.Split(path, "/")
+	if (len(segs) != 8) || (segs[4] != "locale-services") || (segs[6] != serviceType) {
+		// error - this is not a VPN service path
+		return false, "", "", ""
+	}
+
+	if segs[2] != "tier-0s" && segs[2] != "tier-1s" {
+		return false, "", "", ""
+	}
+
+	isT0 := true
+	if segs[2] != "tier-0s" {
+		isT0 = false
+	}
+
+	gwID := segs[3]
+	localeServiceID := segs[5]
+	serviceID := segs[7]
+
+	return isT0, gwID, localeServiceID, serviceID
+}
+
+func parseIPSecVpnServicePolicyPath(path string) (bool, string, string, string) {
+	return parseVpnServicePolicyPath(path, "ipsec-vpn-services")
+}
+
+func parseL2VpnServicePolicyPath(path string) (bool, string, string, string) {
+	return parseVpnServicePolicyPath(path, "l2vpn-services")
+}
+
+func getIPSecVpnSubnetsFromList(subnets []interface{}) []model.IPSecVpnSubnet {
+	subnetList := make([]model.IPSecVpnSubnet, 0)
+	for _, subnet := range interface2StringList(subnets) {
+		cidr := subnet
+		subnetList = append(subnetList, model.IPSecVpnSubnet{Subnet: &cidr})
+	}
+	return subnetList
+}
+
+func getIPSecVpnSubnetsStringList(subnets []model.IPSecVpnSubnet) []string {
+	var subnetList []string
+	for _, subnet := range subnets {
+		if subnet.Subnet != nil {
+			subnetList = append(subnetList, *subnet.Subnet)
+		}
+	}
+	return subnetList
+}
+
+// Derive address of the remote end of a point-to-point tunnel interface subnet.
+// Only /30 and /31 (/126 and /127 for IPv6) subnets have a single possible peer,
+// empty string is returned for any other subnet
+func getIPSecVpnTunnelInterfacePeerAddress(address string, prefixLength int64) string {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return ""
+	}
+
+	bits := int64(128)
+	if ip.To4() != nil {
+		ip = ip.To4()
+		bits = 32
+	}
+
+	peerIP := make(net.IP, len(ip))
+	copy(peerIP, ip)
+	last := len(peerIP) - 1
+	switch prefixLength {
+	case bits - 1:
+		peerIP[last] ^= 1
+	case bits - 2:
+		host := peerIP[last] & 3
+		if host != 1 && host != 2 {
+			// network or broadcast address
+			return ""
+		}
+		peerIP[last] ^= 3
+	default:
+		return ""
+	}
+
+	return peerIP.String()
+}
+
+func parseVpnServiceChildPolicyPath(path string, serviceType string, childType string) (string, string) {
+	// child path must be /infra/tier-Xs/gw-id/locale-services/ls-id/<service-type>/service-id/<child-type>/child-id
+	segs := strings.Split(path, "/")
+	if (len(segs) != 10) || (segs[8] != childType) {
+		return "", ""
+	}
+
+	servicePath := strings.Join(segs[:8], "/")
+	_, gwID, _, _ := parseVpnServicePolicyPath(servicePath, serviceType)
+	if gwID == "" {
+		return "", ""
+	}
+
+	return servicePath, segs[9]
+}
+
+func parseIPSecVpnTunnelInterfacePolicyPath(path string) (string, string, string) {
+	// tunnel interface path must be <session-path>/tunnel-interfaces/vti-id
+	segs := strings.Split(path, "/")
+	if (len(segs) != 12) || (segs[10] != "tunnel-interfaces") {
+		return "", "", ""
+	}
+
+	servicePath, sessionID := parseVpnServiceChildPolicyPath(strings.Join(segs[:10], "/"), "ipsec-vpn-services", "sessions")
+	if sessionID == "" {
+		return "", "", ""
+	}
+
+	return servicePath, sessionID, segs[11]
+}
+
+func getIPSecVpnTunnelInterfacePath(sessionPath string, vti model.IPSecVpnTunnelInterface) string {
+	if vti.Path != nil && *vti.Path != "" {
+		return *vti.Path
+	}
+	if vti.Id == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/tunnel-interfaces/%s", sessionPath, *vti.Id)
+}
+
+func getIPSecVpnTunnelInterfaceFromPath(connector *client.RestConnector, vtiPath string) (*model.IPSecVpnTunnelInterface, error) {
+	servicePath, sessionID, vtiID := parseIPSecVpnTunnelInterfacePolicyPath(vtiPath)
+	if vtiID == "" {
+		return nil, fmt.Errorf("Invalid tunnel interface path %s", vtiPath)
+	}
+
+	isT0, gwID, localeServiceID, serviceID := parseIPSecVpnServicePolicyPath(servicePath)
+	obj, err := getNsxtPolicyIPSecVpnSession(connector, false, isT0, gwID, localeServiceID, serviceID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	converter := bindings.NewTypeConverter()
+	converter.SetMode(bindings.REST)
+	routeVPN, errs := converter.ConvertToGolang(obj, model.RouteBasedIPSecVpnSessionBindingType())
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("Error converting VPN Session %s", errs[0])
+	}
+	routeObj := routeVPN.(model.RouteBasedIPSecVpnSession)
+	if routeObj.ResourceType != model.IPSecVpnSession_RESOURCE_TYPE_ROUTEBASEDIPSECVPNSESSION {
+		return nil, fmt.Errorf("VPN Session %s is not a %s", sessionID, model.IPSecVpnSession_RESOURCE_TYPE_ROUTEBASEDIPSECVPNSESSION)
+	}
+
+	for _, vti := range routeObj.TunnelInterfaces {
+		if vti.Id != nil && *vti.Id == vtiID {
+			return &vti, nil
+		}
+	}
+
+	// tunnel interface was removed from the session
+	return nil, nil
+}
+
+// Select addresses of the tunnel interface that share a subnet with given peer address
+func getIPSecVpnTunnelInterfaceSourceAddresses(vti *model.IPSecVpnTunnelInterface, peerAddress string) []string {
+	peerIP := net.ParseIP(peerAddress)
+	var addresses []string
+	for _, ipSubnet := range vti.IpSubnets {
+		if ipSubnet.PrefixLength == nil {
+			continue
+		}
+		for _, address := range ipSubnet.IpAddresses {
+			_, subnet, err := net.ParseCIDR(fmt.Sprintf("%s/%d", address, *ipSubnet.PrefixLength))
+			if err != nil {
+				continue
+			}
+			if subnet.Contains(peerIP) && address != peerAddress {
+				addresses = append(addresses, address)
+			}
+		}
+	}
+	return addresses
+}
+
+func validateVpnServicePolicyPath(serviceType string) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (s []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %s to be string", k))
+			return
+		}
+
+		_, gwID, _, _ := parseVpnServicePolicyPath(v, serviceType)
+		if gwID == "" {
+			es = append(es, fmt.Errorf("Invalid VPN service path: %s, expected /infra/<tier-0s|tier-1s>/<gateway-id>/locale-services/<locale-service-id>/%s/<service-id>", v, serviceType))
+		}
+
+		return
+	}
+}
+
+func validateL2VpnSessionPolicyPath() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (s []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %s to be string", k))
+			return
+		}
+
+		servicePath, _ := parseVpnServiceChildPolicyPath(v, "l2vpn-services", "sessions")
+		if servicePath == "" {
+			es = append(es, fmt.Errorf("Invalid L2VPN session path: %s", v))
+		}
+
+		return
+	}
+}
+
+func getIPSecVpnRuleElemSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"nsx_id": {
+			Type:        schema.TypeString,
+			Description: "NSX ID of the rule. If not specified, ID of the existing rule in same position in the list is reused, or generated for new rules. Inserting a rule before existing rules shifts their IDs, thus new rules should be appended or have nsx_id specified",
+			Optional:    true,
+			Computed:    true,
+		},
+		"sources": {
+			Type:        schema.TypeSet,
+			Description: "List of local subnets. Specifying no value is interpreted as 0.0.0.0/0.",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validateCidr(),
+			},
+			Required: true,
+		},
+		"destinations": {
+			Type:        schema.TypeSet,
+			Description: "List of remote subnets used in policy-based L3Vpn.",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validateCidr(),
+			},
+			Required: true,
+		},
+		"sequence_number": {
+			Type:        schema.TypeInt,
+			Description: "Sequence number used to resolve conflicts between rules. If not specified, it is assigned by NSX",
+			Optional:    true,
+			Computed:    true,
+		},
+		"enabled": {
+			Type:        schema.TypeBool,
+			Description: "Enable/Disable the rule",
+			Optional:    true,
+			Default:     true,
+		},
+		"logged": {
+			Type:        schema.TypeBool,
+			Description: "Enable logging for the rule",
+			Optional:    true,
+			Default:     false,
+		},
+	}
+}
+
+func getIPSecVpnRulesFromList(rules []interface{}) []model.IPSecVpnRule {
+	var ruleList []model.IPSecVpnRule
+	for _, rule := range rules {
+		data := rule.(map[string]interface{})
+		ruleID := data["nsx_id"].(string)
+		if ruleID == "" {
+			ruleID = newUUID()
+		}
+		enabled := data["enabled"].(bool)
+		logged := data["logged"].(bool)
+		elem := model.IPSecVpnRule{
+			Id:           &ruleID,
+			Sources:      getIPSecVpnSubnetsFromList(data["sources"].(*schema.Set).List()),
+			Destinations: getIPSecVpnSubnetsFromList(data["destinations"].(*schema.Set).List()),
+			Enabled:      &enabled,
+			Logged:       &logged,
+		}
+		if action, ok := data["action"]; ok {
+			actionStr := action.(string)
+			elem.Action = &actionStr
+		}
+		sequenceNumber := int64(data["sequence_number"].(int))
+		if sequenceNumber > 0 {
+			elem.SequenceNumber = &sequenceNumber
+		}
+		ruleList = append(ruleList, elem)
+	}
+	return ruleList
+}
+
+// Compare VPN rule sequence numbers, placing rules without sequence number last
+func vpnRuleSequenceNumberLess(a *int64, b *int64) bool {
+	if a == nil {
+		return false
+	}
+	if b == nil {
+		return true
+	}
+	return *a < *b
+}
+
+// Rules without sequence number are placed last, keeping their original order
+func sortIPSecVpnRulesBySequenceNumber(rules []model.IPSecVpnRule) []model.IPSecVpnRule {
+	sortedRules := make([]model.IPSecVpnRule, len(rules))
+	copy(sortedRules, rules)
+	sort.SliceStable(sortedRules, func(i, j int) bool {
+		return vpnRuleSequenceNumberLess(sortedRules[i].SequenceNumber, sortedRules[j].SequenceNumber)
+	})
+	return sortedRules
+}
+
+func getIPSecVpnRulesList(rules []model.IPSecVpnRule) []map[string]interface{} {
+	var rulesList []map[string]interface{}
+	for _, rule := range rules {
+		elem := make(map[string]interface{})
+		elem["nsx_id"] = rule.Id
+		elem["sources"] = getIPSecVpnSubnetsStringList(rule.Sources)
+		elem["destinations"] = getIPSecVpnSubnetsStringList(rule.Destinations)
+		elem["sequence_number"] = rule.SequenceNumber
+		elem["enabled"] = rule.Enabled
+		elem["logged"] = rule.Logged
+		rulesList = append(rulesList, elem)
+	}
+	return rulesList
+}
+
+func getL3VpnRuleElemSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"nsx_id": {
+			Type:        schema.TypeString,
+			Description: "NSX ID of the rule. If not specified, ID of the existing rule in same position in the list is reused, or generated for new rules",
+			Optional:    true,
+			Computed:    true,
+		},
+		"sources": {
+			Type:        schema.TypeSet,
+			Description: "List of local subnets",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validateCidr(),
+			},
+			Required: true,
+		},
+		"destinations": {
+			Type:        schema.TypeSet,
+			Description: "List of remote subnets",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validateCidr(),
+			},
+			Required: true,
+		},
+		"sequence_number": {
+			Type:        schema.TypeInt,
+			Description: "Sequence number used to resolve conflicts between rules. If not specified, it is assigned by NSX",
+			Optional:    true,
+			Computed:    true,
+		},
+	}
+}
+
+func getL3VpnSubnetsFromList(subnets []interface{}) []model.L3VpnSubnet {
+	var subnetList []model.L3VpnSubnet
+	for _, subnet := range subnets {
+		subnetStr := subnet.(string)
+		subnetList = append(subnetList, model.L3VpnSubnet{Subnet: &subnetStr})
+	}
+	return subnetList
+}
+
+func getL3VpnSubnetsStringList(subnets []model.L3VpnSubnet) []string {
+	var subnetList []string
+	for _, subnet := range subnets {
+		if subnet.Subnet != nil {
+			subnetList = append(subnetList, *subnet.Subnet)
+		}
+	}
+	return subnetList
+}
+
+func getL3VpnRulesFromList(rules []interface{}, action string) []model.L3VpnRule {
+	var ruleList []model.L3VpnRule
+	for _, rule := range rules {
+		data := rule.(map[string]interface{})
+		ruleID := data["nsx_id"].(string)
+		if ruleID == "" {
+			ruleID = newUUID()
+		}
+		elem := model.L3VpnRule{
+			Id:           &ruleID,
+			Action:       &action,
+			Sources:      getL3VpnSubnetsFromList(data["sources"].(*schema.Set).List()),
+			Destinations: getL3VpnSubnetsFromList(data["destinations"].(*schema.Set).List()),
+		}
+		sequenceNumber := int64(data["sequence_number"].(int))
+		if sequenceNumber > 0 {
+			elem.SequenceNumber = &sequenceNumber
+		}
+		ruleList = append(ruleList, elem)
+	}
+	return ruleList
+}
+
+func getL3VpnRulesList(rules []model.L3VpnRule) []map[string]interface{} {
+	sortedRules := make([]model.L3VpnRule, len(rules))
+	copy(sortedRules, rules)
+	sort.SliceStable(sortedRules, func(i, j int) bool {
+		return vpnRuleSequenceNumberLess(sortedRules[i].SequenceNumber, sortedRules[j].SequenceNumber)
+	})
+
+	var rulesList []map[string]interface{}
+	for _, rule := range sortedRules {
+		elem := make(map[string]interface{})
+		elem["nsx_id"] = rule.Id
+		elem["sources"] = getL3VpnSubnetsStringList(rule.Sources)
+		elem["destinations"] = getL3VpnSubnetsStringList(rule.Destinations)
+		elem["sequence_number"] = rule.SequenceNumber
+		rulesList = append(rulesList, elem)
+	}
+	return rulesList
+}
+
+// getVpnSessionStateUpgraders returns upgraders for VPN session state created before
+// service_path was introduced, when the session was identified by tier0_id, locale_service
+// and service_id attributes
+func getVpnSessionStateUpgraders(resource *schema.Resource, serviceType string) []schema.StateUpgrader {
+	v0 := &schema.Resource{Schema: make(map[string]*schema.Schema)}
+	for key, value := range resource.Schema {
+		if key != "service_path" {
+			v0.Schema[key] = value
+		}
+	}
+	for _, key := range []string{"tier0_id", "locale_service", "service_id"} {
+		v0.Schema[key] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		}
+	}
+
+	return []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    v0.CoreConfigSchema().ImpliedType(),
+			Upgrade: func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+				return upgradeVpnSessionStateV0(rawState, serviceType), nil
+			},
+		},
+	}
+}
+
+func upgradeVpnSessionStateV0(rawState map[string]interface{}, serviceType string) map[string]interface{} {
+	if rawState == nil {
+		return rawState
+	}
+
+	// Defaults of the removed attributes
+	values := map[string]string{
+		"tier0_id":       "vmc",
+		"locale_service": "default",
+		"service_id":     "default",
+	}
+	for key := range values {
+		if value, ok := rawState[key].(string); ok && value != "" {
+			values[key] = value
+		}
+		delete(rawState, key)
+	}
+
+	if path, ok := rawState["service_path"].(string); !ok || path == "" {
+		rawState["service_path"] = fmt.Sprintf("/infra/tier-0s/%s/locale-services/%s/%s/%s", values["tier0_id"], values["locale_service"], serviceType, values["service_id"])
+	}
+
+	return rawState
+}
@@ -0,0 +1,115 @@
+/* Copyright © 2021 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func testIPSecVpnRule(id string, sequenceNumber int64) model.IPSecVpnRule {
+	rule := model.IPSecVpnRule{Id: &id}
+	if sequenceNumber > 0 {
+		rule.SequenceNumber = &sequenceNumber
+	}
+	return rule
+}
+
+func TestSortIPSecVpnRulesBySequenceNumber(t *testing.T) {
+	rules := []model.IPSecVpnRule{
+		testIPSecVpnRule("no-seq-1", 0),
+		testIPSecVpnRule("seq-30", 30),
+		testIPSecVpnRule("no-seq-2", 0),
+		testIPSecVpnRule("seq-10", 10),
+		testIPSecVpnRule("seq-20", 20),
+		testIPSecVpnRule("no-seq-3", 0),
+	}
+	expected := []string{"seq-10", "seq-20", "seq-30", "no-seq-1", "no-seq-2", "no-seq-3"}
+
+	// Sorting must be deterministic, rules without sequence number keep their order
+	for i := 0; i < 10; i++ {
+		sortedRules := sortIPSecVpnRulesBySequenceNumber(rules)
+		if len(sortedRules) != len(expected) {
+			t.Fatalf("Expected %d rules, got %d", len(expected), len(sortedRules))
+		}
+		for j, rule := range sortedRules {
+			if *rule.Id != expected[j] {
+				t.Fatalf("Expected rule %s at position %d, got %s", expected[j], j, *rule.Id)
+			}
+		}
+	}
+
+	if *rules[0].Id != "no-seq-1" {
+		t.Errorf("Original rule list should not be modified")
+	}
+}
+
+func TestGetL3VpnRulesListSortsBySequenceNumber(t *testing.T) {
+	var rules []model.L3VpnRule
+	for _, rule := range []model.IPSecVpnRule{
+		testIPSecVpnRule("no-seq-1", 0),
+		testIPSecVpnRule("seq-20", 20),
+		testIPSecVpnRule("seq-10", 10),
+		testIPSecVpnRule("no-seq-2", 0),
+	} {
+		rules = append(rules, model.L3VpnRule{Id: rule.Id, SequenceNumber: rule.SequenceNumber})
+	}
+	expected := []string{"seq-10", "seq-20", "no-seq-1", "no-seq-2"}
+
+	rulesList := getL3VpnRulesList(rules)
+	if len(rulesList) != len(expected) {
+		t.Fatalf("Expected %d rules, got %d", len(expected), len(rulesList))
+	}
+	for i, rule := range rulesList {
+		if *rule["nsx_id"].(*string) != expected[i] {
+			t.Fatalf("Expected rule %s at position %d, got %s", expected[i], i, *rule["nsx_id"].(*string))
+		}
+	}
+}
+
+func TestUpgradeVpnSessionStateV0(t *testing.T) {
+	cases := []struct {
+		state    map[string]interface{}
+		expected string
+	}{
+		{
+			state:    map[string]interface{}{"id": "s1"},
+			expected: "/infra/tier-0s/vmc/locale-services/default/ipsec-vpn-services/default",
+		},
+		{
+			state: map[string]interface{}{
+				"id":             "s2",
+				"tier0_id":       "t0",
+				"locale_service": "ls1",
+				"service_id":     "vpn1",
+			},
+			expected: "/infra/tier-0s/t0/locale-services/ls1/ipsec-vpn-services/vpn1",
+		},
+	}
+
+	for _, c := range cases {
+		state := upgradeVpnSessionStateV0(c.state, "ipsec-vpn-services")
+		if state["service_path"] != c.expected {
+			t.Errorf("Expected service_path %s, got %v", c.expected, state["service_path"])
+		}
+		for _, key := range []string{"tier0_id", "locale_service", "service_id"} {
+			if _, ok := state[key]; ok {
+				t.Errorf("Expected %s to be removed from state", key)
+			}
+		}
+	}
+}
+
+func TestVpnSessionStateUpgraders(t *testing.T) {
+	for _, resource := range []*schema.Resource{resourceNsxtPolicyIPSecVpnSession(), resourceNsxtPolicyL2VPNSession()} {
+		if resource.SchemaVersion != 1 || len(resource.StateUpgraders) != 1 {
+			t.Fatalf("Expected a single state upgrader to version 1")
+		}
+		if !resource.StateUpgraders[0].Type.HasAttribute("tier0_id") || resource.StateUpgraders[0].Type.HasAttribute("service_path") {
+			t.Errorf("Unexpected version 0 state type %s", resource.StateUpgraders[0].Type.GoString())
+		}
+	}
+}